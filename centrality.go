@@ -0,0 +1,57 @@
+package diameter
+
+// RestrictedBetweenness returns for every node the betweenness centrality
+// counted only over shortest paths from one of the sources to one of the
+// targets: the sum over all ordered pairs (s, t) of a source s and a distinct
+// target t of the fraction of shortest s-t paths passing through the node.
+// The ends of a path don't count as passed through. It uses Brandes'
+// accumulation, running one BFS per source and propagating dependencies only
+// from the targets, so it is cheap when there are few sources. With every
+// node as source and target it is the full betweenness, where every
+// unordered pair is counted in both directions. Unknown names are ignored.
+func (g *Graph) RestrictedBetweenness(sources, targets []string) map[string]float64 {
+	isTarget := g.lookupSet(targets)
+	bc := make(map[nodeID]float64, len(g.nodes))
+
+	for s := range g.lookupSet(sources) {
+		// BFS counting shortest paths, remembering the visiting order.
+		sigma := map[nodeID]float64{s: 1}
+		dist := map[nodeID]int{s: 0}
+		preds := make(map[nodeID][]nodeID)
+		order := []nodeID{s}
+		for i := 0; i < len(order); i++ {
+			v := order[i]
+			for w := range g.nodes[v].adj {
+				if _, seen := dist[w]; !seen {
+					dist[w] = dist[v] + 1
+					order = append(order, w)
+				}
+				if dist[w] == dist[v]+1 {
+					sigma[w] += sigma[v]
+					preds[w] = append(preds[w], v)
+				}
+			}
+		}
+
+		// Accumulate the dependencies from the farthest nodes back.
+		delta := make(map[nodeID]float64, len(order))
+		for i := len(order) - 1; i > 0; i-- {
+			w := order[i]
+			credit := delta[w]
+			if isTarget[w] {
+				credit++
+			}
+			for _, v := range preds[w] {
+				delta[v] += sigma[v] / sigma[w] * credit
+			}
+			bc[w] += delta[w]
+		}
+	}
+
+	names := g.symbolTable.names()
+	res := make(map[string]float64, len(g.nodes))
+	for id := range g.nodes {
+		res[string(names[id])] = bc[id]
+	}
+	return res
+}
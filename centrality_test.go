@@ -0,0 +1,80 @@
+package diameter
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRestrictedBetweenness(t *testing.T) {
+	line := edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}}
+	square := edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}, {"d", "a"}}
+	all := []string{"a", "b", "c", "d"}
+
+	tests := []struct {
+		name     string
+		edgeList edgeList
+		sources  []string
+		targets  []string
+		exp      map[string]float64
+	}{
+		{
+			// The full betweenness, every pair counted both ways.
+			name:     "4 in line all pairs",
+			edgeList: line,
+			sources:  all,
+			targets:  all,
+			exp:      map[string]float64{"a": 0, "b": 4, "c": 4, "d": 0},
+		},
+		{
+			name:     "Square all pairs",
+			edgeList: square,
+			sources:  all,
+			targets:  all,
+			exp:      map[string]float64{"a": 1, "b": 1, "c": 1, "d": 1},
+		},
+		{
+			name:     "4 in line single flow",
+			edgeList: line,
+			sources:  []string{"a"},
+			targets:  []string{"d"},
+			exp:      map[string]float64{"a": 0, "b": 1, "c": 1, "d": 0},
+		},
+		{
+			name:     "4 in line short flow",
+			edgeList: line,
+			sources:  []string{"a"},
+			targets:  []string{"c"},
+			exp:      map[string]float64{"a": 0, "b": 1, "c": 0, "d": 0},
+		},
+		{
+			// Two shortest paths, through b and through d.
+			name:     "Square split flow",
+			edgeList: square,
+			sources:  []string{"a"},
+			targets:  []string{"c"},
+			exp:      map[string]float64{"a": 0, "b": 0.5, "c": 0, "d": 0.5},
+		},
+		{
+			name:     "no sources",
+			edgeList: line,
+			targets:  all,
+			exp:      map[string]float64{"a": 0, "b": 0, "c": 0, "d": 0},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := New()
+			test.edgeList.build(g)
+			bc := g.RestrictedBetweenness(test.sources, test.targets)
+			if len(bc) != len(test.exp) {
+				t.Errorf("Number of nodes not as expected. Have %d, expected %d", len(bc), len(test.exp))
+			}
+			for name, exp := range test.exp {
+				if math.Abs(bc[name]-exp) > epsilon {
+					t.Errorf("Betweenness of %s not as expected. Have %f, expected %f", name, bc[name], exp)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,273 @@
+package diameter
+
+import (
+	"math"
+	"sort"
+)
+
+// weightedGraph is a graph with weighted edges on the nodes 0..n-1, used by
+// the community detection to represent aggregated graphs. w[i][j] is the
+// weight between node i and j, w[i][i] is the weight within node i.
+type weightedGraph struct {
+	w []map[int]float64
+}
+
+// degree returns the total weight of the node's edges.
+func (wg weightedGraph) degree(i int) float64 {
+	var k float64
+	for _, w := range wg.w[i] {
+		k += w
+	}
+	return k
+}
+
+// louvain partitions the weighted graph into communities by maximizing the
+// modularity with the given resolution, and returns the community of every
+// node numbered from 0. Higher resolutions favor more and smaller
+// communities.
+//
+// Every pass moves single nodes to the neighboring community with the largest
+// modularity gain until no move improves it, then merges every community into
+// a single node and repeats on the smaller graph, until a pass doesn't move
+// any node.
+func louvain(wg weightedGraph, resolution float64) []int {
+	n := len(wg.w)
+	membership := make([]int, n)
+	for i := range membership {
+		membership[i] = i
+	}
+
+	var m2 float64
+	for i := range wg.w {
+		m2 += wg.degree(i)
+	}
+	if m2 == 0 {
+		return membership
+	}
+
+	for {
+		community, moved := wg.moveNodes(resolution, m2)
+		if !moved {
+			break
+		}
+		for i := range membership {
+			membership[i] = community[membership[i]]
+		}
+		wg = wg.aggregate(community)
+	}
+	return membership
+}
+
+// moveNodes runs the local moving phase of louvain. It returns the community
+// of every node, numbered from 0, and whether any node moved.
+func (wg weightedGraph) moveNodes(resolution, m2 float64) ([]int, bool) {
+	n := len(wg.w)
+	community := make([]int, n)
+	degree := make([]float64, n)
+	total := make([]float64, n)
+	for i := range community {
+		community[i] = i
+		degree[i] = wg.degree(i)
+		total[i] = degree[i]
+	}
+
+	moved := false
+	for improved := true; improved; {
+		improved = false
+		for i := 0; i < n; i++ {
+			// Take the node out of its community.
+			own := community[i]
+			total[own] -= degree[i]
+
+			links := make(map[int]float64)
+			links[own] = 0
+			for j, w := range wg.w[i] {
+				if j != i {
+					links[community[j]] += w
+				}
+			}
+			candidates := make([]int, 0, len(links))
+			for c := range links {
+				candidates = append(candidates, c)
+			}
+			sort.Ints(candidates)
+
+			// The modularity gain of joining community c, up to a constant
+			// factor.
+			gain := func(c int) float64 {
+				return links[c] - resolution*total[c]*degree[i]/m2
+			}
+			best := own
+			for _, c := range candidates {
+				if gain(c) > gain(best)+1e-12 {
+					best = c
+				}
+			}
+
+			community[i] = best
+			total[best] += degree[i]
+			if best != own {
+				improved, moved = true, true
+			}
+		}
+	}
+	return renumber(community), moved
+}
+
+// renumber maps community labels to 0..k-1 in the order of their first node.
+func renumber(community []int) []int {
+	label := make(map[int]int)
+	res := make([]int, len(community))
+	for i, c := range community {
+		if _, ok := label[c]; !ok {
+			label[c] = len(label)
+		}
+		res[i] = label[c]
+	}
+	return res
+}
+
+// aggregate returns the graph with every community merged into one node. The
+// weight between two merged nodes is the sum of the weights between their
+// members.
+func (wg weightedGraph) aggregate(community []int) weightedGraph {
+	k := 0
+	for _, c := range community {
+		if c+1 > k {
+			k = c + 1
+		}
+	}
+	agg := weightedGraph{w: make([]map[int]float64, k)}
+	for c := range agg.w {
+		agg.w[c] = make(map[int]float64)
+	}
+	for i, adj := range wg.w {
+		for j, w := range adj {
+			agg.w[community[i]][community[j]] += w
+		}
+	}
+	return agg
+}
+
+// weighted returns the graph as a weightedGraph with unit weights, with the
+// nodes in ascending id order. Self-loops are left out.
+func (nodes nodes) weighted() (weightedGraph, []nodeID) {
+	ids, index := nodes.index()
+	wg := weightedGraph{w: make([]map[int]float64, len(ids))}
+	for i, id := range ids {
+		wg.w[i] = make(map[int]float64, len(nodes[id].adj))
+		for adj := range nodes[id].adj {
+			if adj != id {
+				wg.w[i][index[adj]] = 1
+			}
+		}
+	}
+	return wg, ids
+}
+
+// MultiResolutionCommunities partitions the graph into communities once for
+// every resolution and returns the partitions by resolution. Each partition
+// maps the node names to community numbers starting at 0. The partitions are
+// found with the Louvain method maximizing the modularity
+//
+//	Q = 1/2m · Σ (Aij - γ·ki·kj/2m) · δ(ci, cj)
+//
+// with resolution γ. A higher resolution yields more and smaller
+// communities, a low one merges them. Resolution 1 is standard modularity.
+// Self-loops are ignored.
+func (g *Graph) MultiResolutionCommunities(resolutions []float64) map[float64]map[string]int {
+	wg, ids := g.nodes.weighted()
+	names := g.symbolTable.names()
+
+	res := make(map[float64]map[string]int, len(resolutions))
+	for _, r := range resolutions {
+		membership := louvain(wg, r)
+		partition := make(map[string]int, len(ids))
+		for i, id := range ids {
+			partition[string(names[id])] = membership[i]
+		}
+		res[r] = partition
+	}
+	return res
+}
+
+// ParticipationCoefficient returns for every node how evenly its edges are
+// spread over the given communities: 1 minus the sum over all communities of
+// the squared fraction of the node's edges leading into that community. A
+// node with all its edges inside one community scores 0, a node spread
+// evenly over many communities scores close to 1. Neighbors without a
+// community and self-loops are ignored, a node without counted edges scores
+// 0.
+func (g *Graph) ParticipationCoefficient(communities map[string]int) map[string]float64 {
+	names := g.symbolTable.names()
+	res := make(map[string]float64, len(g.nodes))
+	for id, n := range g.nodes {
+		perCommunity := make(map[int]int)
+		var k int
+		for adj := range n.adj {
+			c, ok := communities[string(names[adj])]
+			if !ok || adj == id {
+				continue
+			}
+			perCommunity[c]++
+			k++
+		}
+		if k == 0 {
+			res[string(names[id])] = 0
+			continue
+		}
+		p := 1.0
+		for _, kc := range perCommunity {
+			f := float64(kc) / float64(k)
+			p -= f * f
+		}
+		res[string(names[id])] = p
+	}
+	return res
+}
+
+// WithinModuleZScore returns for every node with a community how its degree
+// within its own community compares to that of the other members: the
+// number of its edges into its community minus the community's mean, divided
+// by the community's standard deviation. Together with the participation
+// coefficient it classifies the roles of nodes, hubs of a community score
+// high. Nodes in a community whose members all have the same inner degree
+// score 0. Nodes without a community are left out and self-loops are
+// ignored.
+func (g *Graph) WithinModuleZScore(communities map[string]int) map[string]float64 {
+	names := g.symbolTable.names()
+	inner := make(map[nodeID]int, len(g.nodes))
+	members := make(map[int][]nodeID)
+	for _, id := range g.nodes.ids() {
+		c, ok := communities[string(names[id])]
+		if !ok {
+			continue
+		}
+		for adj := range g.nodes[id].adj {
+			if ca, ok := communities[string(names[adj])]; ok && ca == c && adj != id {
+				inner[id]++
+			}
+		}
+		members[c] = append(members[c], id)
+	}
+
+	res := make(map[string]float64, len(inner))
+	for _, ids := range members {
+		var sum, sumSq float64
+		for _, id := range ids {
+			k := float64(inner[id])
+			sum += k
+			sumSq += k * k
+		}
+		mean := sum / float64(len(ids))
+		stddev := math.Sqrt(math.Max(sumSq/float64(len(ids))-mean*mean, 0))
+		for _, id := range ids {
+			z := 0.0
+			if stddev > 0 {
+				z = (float64(inner[id]) - mean) / stddev
+			}
+			res[string(names[id])] = z
+		}
+	}
+	return res
+}
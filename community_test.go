@@ -0,0 +1,142 @@
+package diameter
+
+import (
+	"math"
+	"testing"
+)
+
+// twoClusters is two K4 joined by the single edge d-e.
+var twoClusters = edgeList{
+	{"a", "b"}, {"a", "c"}, {"a", "d"}, {"b", "c"}, {"b", "d"}, {"c", "d"},
+	{"d", "e"},
+	{"e", "f"}, {"e", "g"}, {"e", "h"}, {"f", "g"}, {"f", "h"}, {"g", "h"},
+}
+
+// countCommunities returns the number of distinct communities in a partition.
+func countCommunities(partition map[string]int) int {
+	distinct := make(map[int]bool)
+	for _, c := range partition {
+		distinct[c] = true
+	}
+	return len(distinct)
+}
+
+func TestMultiResolutionCommunities(t *testing.T) {
+	g := New()
+	twoClusters.build(g)
+
+	res := g.MultiResolutionCommunities([]float64{0.05, 1, 10})
+	if len(res) != 3 {
+		t.Fatalf("Number of partitions not as expected. Have %d, expected 3", len(res))
+	}
+	for r, partition := range res {
+		if len(partition) != len(g.nodes) {
+			t.Errorf("Partition for %g has %d nodes, expected %d", r, len(partition), len(g.nodes))
+		}
+	}
+
+	if c := countCommunities(res[0.05]); c != 1 {
+		t.Errorf("Expected a single community at low resolution, have %d: %v", c, res[0.05])
+	}
+
+	p := res[1]
+	if c := countCommunities(p); c != 2 {
+		t.Errorf("Expected two communities at resolution 1, have %d: %v", c, p)
+	}
+	for _, n := range "bcd" {
+		if p[string(n)] != p["a"] {
+			t.Errorf("Expected %c in the community of a: %v", n, p)
+		}
+	}
+	for _, n := range "fgh" {
+		if p[string(n)] != p["e"] {
+			t.Errorf("Expected %c in the community of e: %v", n, p)
+		}
+	}
+	if p["a"] == p["e"] {
+		t.Errorf("Expected the clusters in separate communities: %v", p)
+	}
+
+	if c := countCommunities(res[10]); c <= 2 {
+		t.Errorf("Expected more than two communities at high resolution, have %d: %v", c, res[10])
+	}
+}
+
+func TestMultiResolutionCommunitiesEmpty(t *testing.T) {
+	g := New()
+	res := g.MultiResolutionCommunities([]float64{1})
+	if len(res[1]) != 0 {
+		t.Errorf("Expected an empty partition, have %v", res[1])
+	}
+}
+
+func TestParticipationCoefficient(t *testing.T) {
+	// Two triangles a,b,c and d,e,f, with connector x linked to a, b, d and
+	// e.
+	g := New()
+	edgeList{
+		{"a", "b"}, {"b", "c"}, {"a", "c"},
+		{"d", "e"}, {"e", "f"}, {"d", "f"},
+		{"x", "a"}, {"x", "b"}, {"x", "d"}, {"x", "e"},
+	}.build(g)
+	communities := map[string]int{"a": 0, "b": 0, "c": 0, "d": 1, "e": 1, "f": 1, "x": 2}
+
+	p := g.ParticipationCoefficient(communities)
+	exp := map[string]float64{
+		"x": 0.5,
+		"c": 0,
+		"f": 0,
+		// Two edges inside, one to x.
+		"a": 1 - (4.0/9.0 + 1.0/9.0),
+	}
+	for name, e := range exp {
+		if math.Abs(p[name]-e) > epsilon {
+			t.Errorf("Participation coefficient of %s not as expected. Have %f, expected %f", name, p[name], e)
+		}
+	}
+	if len(p) != len(g.nodes) {
+		t.Errorf("Number of nodes not as expected. Have %d, expected %d", len(p), len(g.nodes))
+	}
+	for name, v := range p {
+		if name != "x" && v >= p["x"] {
+			t.Errorf("Expected the connector to score highest, %s scores %f against %f", name, v, p["x"])
+		}
+	}
+}
+
+func TestWithinModuleZScore(t *testing.T) {
+	// Hub h in a community with a, b, c and d, and the triangle x, y, z as a
+	// second community. The edge h-x crosses communities and doesn't count.
+	g := New()
+	edgeList{
+		{"h", "a"}, {"h", "b"}, {"h", "c"}, {"h", "d"}, {"a", "b"},
+		{"x", "y"}, {"y", "z"}, {"x", "z"},
+		{"h", "x"},
+	}.build(g)
+	g.AddNode("lonely")
+	communities := map[string]int{"h": 0, "a": 0, "b": 0, "c": 0, "d": 0, "x": 1, "y": 1, "z": 1}
+
+	z := g.WithinModuleZScore(communities)
+	// Inner degrees 4, 2, 2, 1, 1 with mean 2 and variance 1.2.
+	sd := math.Sqrt(1.2)
+	exp := map[string]float64{
+		"h": 2 / sd,
+		"a": 0,
+		"c": -1 / sd,
+		"x": 0,
+		"y": 0,
+	}
+	for name, e := range exp {
+		if math.Abs(z[name]-e) > epsilon {
+			t.Errorf("Z-score of %s not as expected. Have %f, expected %f", name, z[name], e)
+		}
+	}
+	if len(z) != len(communities) {
+		t.Errorf("Number of nodes not as expected. Have %d, expected %d", len(z), len(communities))
+	}
+	for name, v := range z {
+		if name != "h" && v >= z["h"] {
+			t.Errorf("Expected the hub to score highest, %s scores %f against %f", name, v, z["h"])
+		}
+	}
+}
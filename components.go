@@ -0,0 +1,274 @@
+package diameter
+
+import "math/rand"
+
+// components labels every node with the connected component it belongs to.
+// Components are numbered from 0 in the order of their lowest node id. It
+// returns the labels and the size of each component.
+func (nodes nodes) components() (map[nodeID]int, []int) {
+	label := make(map[nodeID]int, len(nodes))
+	var sizes []int
+	for _, start := range nodes.ids() {
+		if _, ok := label[start]; ok {
+			continue
+		}
+		c := len(sizes)
+		label[start] = c
+		size := 0
+		stack := []nodeID{start}
+		for len(stack) > 0 {
+			id := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			size++
+			for adj := range nodes[id].adj {
+				if _, ok := label[adj]; !ok {
+					label[adj] = c
+					stack = append(stack, adj)
+				}
+			}
+		}
+		sizes = append(sizes, size)
+	}
+	return label, sizes
+}
+
+// ReachableCounts returns for every node the number of other nodes reachable
+// from it. In an undirected graph these are exactly the other members of its
+// connected component, so a single labelling pass in O(V+E) is enough.
+func (g *Graph) ReachableCounts() map[string]int {
+	label, sizes := g.nodes.components()
+	names := g.symbolTable.names()
+	counts := make(map[string]int, len(label))
+	for id, c := range label {
+		counts[string(names[id])] = sizes[c] - 1
+	}
+	return counts
+}
+
+// ConnectedComponents returns the names of the nodes of every connected
+// component. Components are ordered by their first added node and the names
+// within a component in the order the nodes were added.
+func (g *Graph) ConnectedComponents() [][]string {
+	label, sizes := g.nodes.components()
+	members := make([][]nodeID, len(sizes))
+	for _, id := range g.nodes.ids() {
+		members[label[id]] = append(members[label[id]], id)
+	}
+	res := make([][]string, len(members))
+	for c, ids := range members {
+		res[c] = g.symbolTable.toStrings(ids)
+	}
+	return res
+}
+
+// IsConnected reports whether every node can be reached from every other
+// node. The empty graph is connected.
+func (g *Graph) IsConnected() bool {
+	_, sizes := g.nodes.components()
+	return len(sizes) <= 1
+}
+
+// DiameterPerComponent returns the diameter of every connected component,
+// keyed by the component's index in ConnectedComponents. Diameter returns the
+// largest of them.
+func (g *Graph) DiameterPerComponent() map[int]int {
+	label, sizes := g.nodes.components()
+	res := make(map[int]int, len(sizes))
+	for c := range sizes {
+		res[c] = 0
+	}
+	s := g.nodes.newBFSScratch()
+	for id, c := range label {
+		if df := g.nodes.longestShortestPath(s, id); df > res[c] {
+			res[c] = df
+		}
+	}
+	return res
+}
+
+// bridges returns the edges whose removal disconnects their two ends, found
+// with Tarjan's lowlink depth first search. The edges are returned with the
+// lower id first in ascending order.
+func (nodes nodes) bridges() []nodePair {
+	order := make(map[nodeID]int, len(nodes))
+	low := make(map[nodeID]int, len(nodes))
+	var bridges []nodePair
+
+	var visit func(id, parent nodeID, root bool)
+	visit = func(id, parent nodeID, root bool) {
+		order[id] = len(order)
+		low[id] = order[id]
+		for adj := range nodes[id].adj {
+			if adj == id || (!root && adj == parent) {
+				continue
+			}
+			if _, seen := order[adj]; seen {
+				if order[adj] < low[id] {
+					low[id] = order[adj]
+				}
+				continue
+			}
+			visit(adj, id, false)
+			if low[adj] < low[id] {
+				low[id] = low[adj]
+			}
+			if low[adj] > order[id] {
+				bridges = append(bridges, newNodePair(id, adj))
+			}
+		}
+	}
+	for _, id := range nodes.ids() {
+		if _, seen := order[id]; !seen {
+			visit(id, id, true)
+		}
+	}
+
+	sortPairs(bridges)
+	return bridges
+}
+
+// TwoEdgeConnectedCore returns the subgraph left after repeatedly removing
+// all bridges and the nodes that are left with at most one neighbor, until
+// neither remain. Every edge of the core lies on a cycle. The core of a tree
+// is empty, a cycle is its own core.
+func (g *Graph) TwoEdgeConnectedCore() *Graph {
+	core := g.nodes.clone()
+	for {
+		changed := false
+		for _, b := range core.bridges() {
+			core.removeEdge(b[0], b[1])
+			changed = true
+		}
+		for _, id := range core.ids() {
+			if core.degree(id) <= 1 {
+				core.removeNode(id)
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+	return g.subgraph(core)
+}
+
+// ReliabilityEstimate estimates the probability that node a and b are still
+// connected when every node, a and b included, fails independently with
+// probability p. It runs the given number of Monte Carlo trials, each removing
+// a random set of nodes and checking whether b can be reached from a, and
+// returns the fraction of trials in which it could. The same seed always
+// yields the same estimate. It returns 0 for unknown nodes or no trials.
+func (g *Graph) ReliabilityEstimate(a, b string, p float64, trials int, seed int64) float64 {
+	from, err := g.find(a)
+	if err != nil || trials <= 0 {
+		return 0
+	}
+	to, err := g.find(b)
+	if err != nil {
+		return 0
+	}
+
+	rnd := rand.New(rand.NewSource(seed))
+	ids := g.nodes.ids()
+	failed := make(map[nodeID]bool, len(ids))
+	var connected int
+	for trial := 0; trial < trials; trial++ {
+		// Draw for the nodes in a fixed order to make the seed reproducible.
+		for _, id := range ids {
+			failed[id] = rnd.Float64() < p
+		}
+		if failed[from] || failed[to] {
+			continue
+		}
+		if g.nodes.reachable(from, to, func(_, adj nodeID) bool { return failed[adj] }) {
+			connected++
+		}
+	}
+	return float64(connected) / float64(trials)
+}
+
+// reachable reports whether node to can be reached from node from without
+// using any edge for which skip returns true. skip is called with the node
+// the edge is taken from and the node it leads to.
+func (nodes nodes) reachable(from, to nodeID, skip func(id, adj nodeID) bool) bool {
+	seen := map[nodeID]bool{from: true}
+	stack := []nodeID{from}
+	for len(stack) > 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if id == to {
+			return true
+		}
+		for adj := range nodes[id].adj {
+			if !seen[adj] && !skip(id, adj) {
+				seen[adj] = true
+				stack = append(stack, adj)
+			}
+		}
+	}
+	return false
+}
+
+// IsBridge reports whether the edge between node a and b is a bridge, i.e.
+// whether a and b can no longer reach each other without it. The edge is
+// skipped during the search rather than removed, so the graph is never
+// modified. It returns false if there is no such edge.
+func (g *Graph) IsBridge(a, b string) bool {
+	from, err := g.find(a)
+	if err != nil {
+		return false
+	}
+	to, err := g.find(b)
+	if err != nil || from == to || !g.nodes.adjacent(from, to) {
+		return false
+	}
+	edge := newNodePair(from, to)
+	return !g.nodes.reachable(from, to, func(id, adj nodeID) bool { return newNodePair(id, adj) == edge })
+}
+
+// ConnectivityAfterRemovals removes the edges of every batch from the graph in
+// turn and returns the number of connected components after each batch.
+// Union-find can't delete edges, so the batches are processed offline in
+// reverse: starting from the graph without any of the removed edges, the
+// batches are added back from last to first. Edges that don't exist are
+// ignored. The graph itself is not modified.
+func (g *Graph) ConnectivityAfterRemovals(batches [][][2]string) []int {
+	// The batch in which each edge is removed first.
+	removed := make(map[nodePair]int)
+	for i, batch := range batches {
+		for _, e := range batch {
+			a, aerr := g.find(e[0])
+			b, berr := g.find(e[1])
+			if aerr != nil || berr != nil || !g.nodes.adjacent(a, b) {
+				continue
+			}
+			p := newNodePair(a, b)
+			if _, ok := removed[p]; !ok {
+				removed[p] = i
+			}
+		}
+	}
+
+	components := len(g.nodes)
+	u := make(unionFind)
+	for _, e := range g.nodes.edges() {
+		if _, ok := removed[e]; !ok && u.union(e[0], e[1]) {
+			components--
+		}
+	}
+
+	readd := make([][]nodePair, len(batches))
+	for e, i := range removed {
+		readd[i] = append(readd[i], e)
+	}
+	counts := make([]int, len(batches))
+	for i := len(batches) - 1; i >= 0; i-- {
+		counts[i] = components
+		for _, e := range readd[i] {
+			if u.union(e[0], e[1]) {
+				components--
+			}
+		}
+	}
+	return counts
+}
@@ -0,0 +1,345 @@
+package diameter
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestReachableCounts(t *testing.T) {
+
+	tests := []struct {
+		name      string
+		edgeList  edgeList
+		expCounts map[string]int
+	}{
+		{
+			name:      "empty",
+			expCounts: map[string]int{},
+		},
+		{
+			name:      "1 edge",
+			edgeList:  edgeList{{"a", "b"}},
+			expCounts: map[string]int{"a": 1, "b": 1},
+		},
+		{
+			name:      "two components",
+			edgeList:  edgeList{{"a", "b"}, {"b", "c"}, {"d", "e"}},
+			expCounts: map[string]int{"a": 2, "b": 2, "c": 2, "d": 1, "e": 1},
+		},
+		{
+			name:      "self-loop",
+			edgeList:  edgeList{{"a", "a"}, {"b", "c"}},
+			expCounts: map[string]int{"a": 0, "b": 1, "c": 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := New()
+			test.edgeList.build(g)
+			counts := g.ReachableCounts()
+			if len(counts) != len(test.expCounts) {
+				t.Errorf("Number of nodes not as expected. Have %d, expected %d", len(counts), len(test.expCounts))
+			}
+			for name, exp := range test.expCounts {
+				if counts[name] != exp {
+					t.Errorf("Reachable count of %s not as expected. Have %d, expected %d", name, counts[name], exp)
+				}
+			}
+		})
+	}
+}
+
+func TestTwoEdgeConnectedCore(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		edgeList edgeList
+		expCore  edgeList
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:     "tree",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"b", "d"}, {"d", "e"}},
+		},
+		{
+			name:     "cycle",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}, {"d", "a"}},
+			expCore:  edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}, {"d", "a"}},
+		},
+		{
+			name: "barbell",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"a", "c"}, {"c", "d"}, {"d", "e"},
+				{"e", "f"}, {"f", "g"}, {"e", "g"}},
+			expCore: edgeList{{"a", "b"}, {"b", "c"}, {"a", "c"}, {"e", "f"}, {"f", "g"}, {"e", "g"}},
+		},
+		{
+			name:     "cycle with tails",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"c", "a"}, {"a", "x"}, {"x", "y"}, {"b", "z"}},
+			expCore:  edgeList{{"a", "b"}, {"b", "c"}, {"c", "a"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := New()
+			test.edgeList.build(g)
+			core := g.TwoEdgeConnectedCore()
+
+			exp := New()
+			test.expCore.build(exp)
+			if len(core.nodes) != len(exp.nodes) {
+				t.Errorf("Number of nodes not as expected. Have %d, expected %d", len(core.nodes), len(exp.nodes))
+			}
+			if len(core.nodes.edges()) != len(exp.nodes.edges()) {
+				t.Errorf("Number of edges not as expected. Have %d, expected %d", len(core.nodes.edges()), len(exp.nodes.edges()))
+			}
+			for _, e := range test.expCore {
+				a, aok := core.symbolTable.lookup(e.a)
+				b, bok := core.symbolTable.lookup(e.b)
+				if !aok || !bok || !core.nodes.adjacent(a, b) {
+					t.Errorf("Edge %s-%s missing from the core", e.a, e.b)
+				}
+			}
+			// The original graph is left untouched.
+			if len(g.nodes) != len(g.symbolTable.byName) {
+				t.Errorf("Graph was modified")
+			}
+		})
+	}
+}
+
+func TestReliabilityEstimate(t *testing.T) {
+	// Two routes from s to t.
+	parallel := New()
+	edgeList{{"s", "a"}, {"a", "t"}, {"s", "b"}, {"b", "t"}}.build(parallel)
+	// One route from s to t.
+	single := New()
+	edgeList{{"s", "a"}, {"a", "t"}}.build(single)
+
+	const trials = 2000
+	rp := parallel.ReliabilityEstimate("s", "t", 0.3, trials, 1)
+	rs := single.ReliabilityEstimate("s", "t", 0.3, trials, 1)
+	if rp <= rs {
+		t.Errorf("Expected two routes to be more reliable than one. Have %f for two routes and %f for one", rp, rs)
+	}
+
+	if r := parallel.ReliabilityEstimate("s", "t", 0.3, trials, 1); r != rp {
+		t.Errorf("Estimate not reproducible with the same seed. Have %f and %f", r, rp)
+	}
+	if r := parallel.ReliabilityEstimate("s", "t", 0, trials, 1); r != 1 {
+		t.Errorf("Expected reliability 1 without failures, have %f", r)
+	}
+	if r := parallel.ReliabilityEstimate("s", "t", 1, trials, 1); r != 0 {
+		t.Errorf("Expected reliability 0 when all nodes fail, have %f", r)
+	}
+	if r := parallel.ReliabilityEstimate("s", "unknown", 0, trials, 1); r != 0 {
+		t.Errorf("Expected reliability 0 for unknown nodes, have %f", r)
+	}
+}
+
+func TestIsBridge(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		edgeList edgeList
+		a, b     string
+		exp      bool
+	}{
+		{
+			name:     "cycle",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}, {"d", "a"}},
+			a:        "a",
+			b:        "b",
+		},
+		{
+			name:     "path end",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}},
+			a:        "a",
+			b:        "b",
+			exp:      true,
+		},
+		{
+			name:     "path middle",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}},
+			a:        "c",
+			b:        "b",
+			exp:      true,
+		},
+		{
+			name:     "barbell bridge",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"a", "c"}, {"c", "d"}, {"d", "e"}, {"e", "f"}, {"d", "f"}},
+			a:        "c",
+			b:        "d",
+			exp:      true,
+		},
+		{
+			name:     "no such edge",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}},
+			a:        "a",
+			b:        "c",
+		},
+		{
+			name:     "self-loop",
+			edgeList: edgeList{{"a", "a"}, {"a", "b"}},
+			a:        "a",
+			b:        "a",
+		},
+		{
+			name:     "unknown",
+			edgeList: edgeList{{"a", "b"}},
+			a:        "a",
+			b:        "x",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := New()
+			test.edgeList.build(g)
+			if b := g.IsBridge(test.a, test.b); b != test.exp {
+				t.Errorf("Bridge not as expected. Have %t, expected %t", b, test.exp)
+			}
+			// Every edge must agree with the bridges found by Tarjan's
+			// algorithm.
+			bridges := make(map[nodePair]bool)
+			for _, b := range g.nodes.bridges() {
+				bridges[b] = true
+			}
+			for _, e := range g.nodes.edges() {
+				names := g.symbolTable.names()
+				if g.IsBridge(string(names[e[0]]), string(names[e[1]])) != bridges[e] {
+					t.Errorf("IsBridge disagrees with bridges for %s-%s", names[e[0]], names[e[1]])
+				}
+			}
+		})
+	}
+}
+
+func TestConnectivityAfterRemovals(t *testing.T) {
+	cycle := edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}, {"d", "e"}, {"e", "a"}}
+
+	tests := []struct {
+		name    string
+		batches [][][2]string
+		exp     []int
+	}{
+		{
+			name: "no batches",
+			exp:  []int{},
+		},
+		{
+			name:    "one at a time",
+			batches: [][][2]string{{{"a", "b"}}, {{"b", "c"}}, {{"c", "d"}}, {{"d", "e"}}, {{"e", "a"}}},
+			exp:     []int{1, 2, 3, 4, 5},
+		},
+		{
+			name:    "batched",
+			batches: [][][2]string{{{"a", "b"}, {"c", "d"}}, {{"e", "a"}}},
+			exp:     []int{2, 3},
+		},
+		{
+			name:    "repeated and unknown",
+			batches: [][][2]string{{{"b", "a"}}, {{"a", "b"}, {"a", "c"}, {"x", "y"}}, {{"d", "c"}}},
+			exp:     []int{1, 1, 2},
+		},
+	}
+
+	g := New()
+	cycle.build(g)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			counts := g.ConnectivityAfterRemovals(test.batches)
+			if len(counts) != len(test.exp) {
+				t.Fatalf("Counts not as expected. Have %v, expected %v", counts, test.exp)
+			}
+			for i := range counts {
+				if counts[i] != test.exp[i] {
+					t.Fatalf("Counts not as expected. Have %v, expected %v", counts, test.exp)
+				}
+			}
+		})
+	}
+	if len(g.nodes.edges()) != len(cycle) {
+		t.Errorf("Graph was modified")
+	}
+}
+
+func TestConnectedComponents(t *testing.T) {
+
+	tests := []struct {
+		name          string
+		edgeList      edgeList
+		isolated      []string
+		expComponents [][]string
+		expDiameters  map[int]int
+	}{
+		{
+			name:          "empty",
+			expComponents: [][]string{},
+			expDiameters:  map[int]int{},
+		},
+		{
+			name:          "connected",
+			edgeList:      edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}},
+			expComponents: [][]string{{"a", "b", "c", "d"}},
+			expDiameters:  map[int]int{0: 3},
+		},
+		{
+			name:          "two triangles",
+			edgeList:      edgeList{{"a", "b"}, {"b", "c"}, {"c", "a"}, {"x", "y"}, {"y", "z"}, {"z", "x"}},
+			expComponents: [][]string{{"a", "b", "c"}, {"x", "y", "z"}},
+			expDiameters:  map[int]int{0: 1, 1: 1},
+		},
+		{
+			name:          "line, edge and isolated node",
+			edgeList:      edgeList{{"x", "y"}, {"a", "b"}, {"b", "c"}, {"c", "d"}},
+			isolated:      []string{"lonely"},
+			expComponents: [][]string{{"x", "y"}, {"a", "b", "c", "d"}, {"lonely"}},
+			expDiameters:  map[int]int{0: 1, 1: 3, 2: 0},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := New()
+			test.edgeList.build(g)
+			for _, name := range test.isolated {
+				g.AddNode(name)
+			}
+
+			components := g.ConnectedComponents()
+			if len(components) != len(test.expComponents) {
+				t.Fatalf("Components not as expected. Have %v, expected %v", components, test.expComponents)
+			}
+			for i := range components {
+				if fmt.Sprint(components[i]) != fmt.Sprint(test.expComponents[i]) {
+					t.Errorf("Component %d not as expected. Have %v, expected %v", i, components[i], test.expComponents[i])
+				}
+			}
+
+			if c := g.IsConnected(); c != (len(test.expComponents) <= 1) {
+				t.Errorf("Connected not as expected. Have %t, expected %t", c, !c)
+			}
+
+			diameters := g.DiameterPerComponent()
+			if len(diameters) != len(test.expDiameters) {
+				t.Errorf("Number of diameters not as expected. Have %d, expected %d", len(diameters), len(test.expDiameters))
+			}
+			max := 0
+			for c, e := range test.expDiameters {
+				if diameters[c] != e {
+					t.Errorf("Diameter of component %d not as expected. Have %d, expected %d", c, diameters[c], e)
+				}
+				if e > max {
+					max = e
+				}
+			}
+			if d := g.Diameter(); d != max {
+				t.Errorf("Diameter not as expected. Have %d, expected %d", d, max)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package diameter
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+)
+
+// WriteCSV writes the graph as CSV with one record per edge holding the names
+// of its two nodes, and one record holding just the name of every node
+// without edges. Records are written in the order the nodes were added to the
+// graph, with the edges of a node sorted by the order of their other end.
+func (g *Graph) WriteCSV(w io.Writer) error {
+	names := g.symbolTable.names()
+	cw := csv.NewWriter(w)
+	for _, id := range g.nodes.ids() {
+		if len(g.nodes[id].adj) == 0 {
+			cw.Write([]string{string(names[id])})
+			continue
+		}
+		for _, adj := range g.nodes.neighbors(id) {
+			if adj >= id {
+				cw.Write([]string{string(names[id]), string(names[adj])})
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// ReadCSV reads a graph written by WriteCSV. Every record holds the names of
+// the two nodes of an edge, or the name of a single node to add without
+// edges. Records with more fields return an error wrapping ErrMalformedEdge.
+func ReadCSV(r io.Reader) (*Graph, error) {
+	g := New()
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return g, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch len(record) {
+		case 1:
+			g.AddNode(record[0])
+		case 2:
+			g.AddEdge(record[0], record[1])
+		default:
+			line, _ := cr.FieldPos(0)
+			return nil, fmt.Errorf("line %d: %q: %w", line, record, ErrMalformedEdge)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package diameter
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestWriteCSV(t *testing.T) {
+	g := New()
+	edgeList{{"a", "b"}, {"b", "c"}, {"c", "a"}, {"x, y", "x, y"}}.build(g)
+	g.AddNode("lonely")
+
+	exp := "a,b\na,c\nb,c\n\"x, y\",\"x, y\"\nlonely\n"
+	var b bytes.Buffer
+	if err := g.WriteCSV(&b); err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != exp {
+		t.Errorf("Output not as expected. Have\n%s\nexpected\n%s", b.String(), exp)
+	}
+	if err := g.WriteCSV(failingWriter{}); err == nil {
+		t.Errorf("Expected the write error to be returned")
+	}
+}
+
+func TestReadCSV(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		input    string
+		expNodes int
+		expEdges edgeList
+		expErr   error
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:     "edges and isolated node",
+			input:    "a,b\nb,c\n\"new, york\",a\nlonely\n",
+			expNodes: 5,
+			expEdges: edgeList{{"a", "b"}, {"b", "c"}, {"new, york", "a"}},
+		},
+		{
+			name:   "too many fields",
+			input:  "a,b\na,b,c\n",
+			expErr: ErrMalformedEdge,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g, err := ReadCSV(strings.NewReader(test.input))
+			if !errors.Is(err, test.expErr) {
+				t.Fatalf("Error not as expected. Have %v, expected %v", err, test.expErr)
+			}
+			if err != nil {
+				return
+			}
+			if len(g.nodes) != test.expNodes {
+				t.Errorf("Number of nodes not as expected. Have %d, expected %d", len(g.nodes), test.expNodes)
+			}
+			if e := len(g.nodes.edges()); e != len(test.expEdges) {
+				t.Errorf("Number of edges not as expected. Have %d, expected %d", e, len(test.expEdges))
+			}
+			for _, e := range test.expEdges {
+				if !g.nodes.adjacent(g.symbolTable.byName[e.a], g.symbolTable.byName[e.b]) {
+					t.Errorf("Edge %s-%s missing", e.a, e.b)
+				}
+			}
+		})
+	}
+}
+
+func TestCSVRoundTrip(t *testing.T) {
+	g := New()
+	edgeList{{"a", "b"}, {"b", "c"}, {"c", "a"}, {"c", "d"}, {"d", "e"}, {"e", "c"}, {"x \"y\"", "x \"y\""}}.build(g)
+	g.AddNode("lonely")
+
+	var first bytes.Buffer
+	if err := g.WriteCSV(&first); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := ReadCSV(bytes.NewReader(first.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var second bytes.Buffer
+	if err := loaded.WriteCSV(&second); err != nil {
+		t.Fatal(err)
+	}
+	if first.String() != second.String() {
+		t.Errorf("Round trip not as expected. Have\n%s\nexpected\n%s", second.String(), first.String())
+	}
+	if loaded.Diameter() != g.Diameter() {
+		t.Errorf("Diameter not as expected. Have %d, expected %d", loaded.Diameter(), g.Diameter())
+	}
+}
@@ -0,0 +1,54 @@
+package diameter
+
+// AverageNeighborDegree returns for every node the mean degree of its
+// neighbors. Self-loops are ignored and a node without neighbors has an
+// average neighbor degree of 0.
+func (g *Graph) AverageNeighborDegree() map[string]float64 {
+	names := g.symbolTable.names()
+	avg := make(map[string]float64, len(g.nodes))
+	for id, n := range g.nodes {
+		var sum, count int
+		for adj := range n.adj {
+			if adj == id {
+				continue
+			}
+			sum += g.nodes.degree(adj)
+			count++
+		}
+		if count > 0 {
+			avg[string(names[id])] = float64(sum) / float64(count)
+		} else {
+			avg[string(names[id])] = 0
+		}
+	}
+	return avg
+}
+
+// RichClubCoefficient returns the rich-club coefficient of the graph for
+// degree k, the edge density among the nodes with a degree greater than k. A
+// value close to 1 means the high degree nodes are tightly interconnected. It
+// is 0 when less than two nodes have a degree greater than k.
+func (g *Graph) RichClubCoefficient(k int) float64 {
+	rich := make(map[nodeID]bool)
+	for id := range g.nodes {
+		if g.nodes.degree(id) > k {
+			rich[id] = true
+		}
+	}
+	return g.nodes.density(rich)
+}
+
+// IsComplete reports whether every pair of distinct nodes is connected by an
+// edge and no node has a self-loop. The empty graph is complete.
+func (g *Graph) IsComplete() bool {
+	var degrees int
+	for id, n := range g.nodes {
+		if g.nodes.adjacent(id, id) {
+			return false
+		}
+		degrees += len(n.adj)
+	}
+	v := len(g.nodes)
+	// Every edge adds to the degree of both its ends.
+	return degrees/2 == v*(v-1)/2
+}
@@ -0,0 +1,142 @@
+package diameter
+
+import "testing"
+
+func TestAverageNeighborDegree(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		edgeList edgeList
+		exp      map[string]float64
+	}{
+		{
+			name: "empty",
+			exp:  map[string]float64{},
+		},
+		{
+			name:     "Star",
+			edgeList: edgeList{{"h", "a"}, {"h", "b"}, {"h", "c"}, {"h", "d"}},
+			exp:      map[string]float64{"h": 1, "a": 4, "b": 4, "c": 4, "d": 4},
+		},
+		{
+			name:     "3 in line",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}},
+			exp:      map[string]float64{"a": 2, "b": 1, "c": 2},
+		},
+		{
+			name:     "self-loop",
+			edgeList: edgeList{{"a", "a"}, {"b", "c"}, {"c", "d"}},
+			exp:      map[string]float64{"a": 0, "b": 2, "c": 1, "d": 2},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := New()
+			test.edgeList.build(g)
+			avg := g.AverageNeighborDegree()
+			if len(avg) != len(test.exp) {
+				t.Errorf("Number of nodes not as expected. Have %d, expected %d", len(avg), len(test.exp))
+			}
+			for name, exp := range test.exp {
+				if avg[name] != exp {
+					t.Errorf("Average neighbor degree of %s not as expected. Have %f, expected %f", name, avg[name], exp)
+				}
+			}
+		})
+	}
+}
+
+func TestRichClubCoefficient(t *testing.T) {
+	// Two connected hubs h1 and h2 with three leaves each, plus a third hub
+	// h3 with two leaves connected to h1 only.
+	el := edgeList{
+		{"h1", "h2"},
+		{"h1", "a"}, {"h1", "b"}, {"h1", "c"},
+		{"h2", "d"}, {"h2", "e"}, {"h2", "f"},
+		{"h3", "h1"}, {"h3", "g"}, {"h3", "h"},
+	}
+
+	tests := []struct {
+		name string
+		k    int
+		exp  float64
+	}{
+		{
+			name: "hubs",
+			k:    3,
+			exp:  1,
+		},
+		{
+			name: "all hubs",
+			k:    2,
+			exp:  2.0 / 3.0,
+		},
+		{
+			name: "single node",
+			k:    4,
+		},
+		{
+			name: "none",
+			k:    10,
+		},
+	}
+
+	g := New()
+	el.build(g)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := g.RichClubCoefficient(test.k)
+			if c != test.exp {
+				t.Errorf("Rich-club coefficient not as expected. Have %f, expected %f", c, test.exp)
+			}
+		})
+	}
+}
+
+func TestIsComplete(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		edgeList edgeList
+		exp      bool
+	}{
+		{
+			name: "empty",
+			exp:  true,
+		},
+		{
+			name:     "1 edge",
+			edgeList: edgeList{{"a", "b"}},
+			exp:      true,
+		},
+		{
+			name:     "Triangle",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"a", "c"}},
+			exp:      true,
+		},
+		{
+			name:     "4 in line",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}},
+		},
+		{
+			name:     "K4",
+			edgeList: edgeList{{"a", "b"}, {"a", "c"}, {"a", "d"}, {"b", "c"}, {"b", "d"}, {"c", "d"}},
+			exp:      true,
+		},
+		{
+			name:     "Triangle with self-loop",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"a", "c"}, {"a", "a"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := New()
+			test.edgeList.build(g)
+			if c := g.IsComplete(); c != test.exp {
+				t.Errorf("Completeness not as expected. Have %t, expected %t", c, test.exp)
+			}
+		})
+	}
+}
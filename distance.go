@@ -0,0 +1,271 @@
+package diameter
+
+import "math"
+
+// distances runs a BFS from start and returns the distance to every node
+// reachable from it, including start itself at distance 0.
+func (nodes nodes) distances(start nodeID) map[nodeID]int {
+	dist := map[nodeID]int{start: 0}
+	queue := []nodeID{start}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for adj := range nodes[id].adj {
+			if _, seen := dist[adj]; !seen {
+				dist[adj] = dist[id] + 1
+				queue = append(queue, adj)
+			}
+		}
+	}
+	return dist
+}
+
+// distanceHistogram counts the unordered pairs of distinct nodes by the length
+// of the shortest path between them. Pairs that can't reach each other are
+// not counted.
+func (nodes nodes) distanceHistogram() map[int]int {
+	hist := make(map[int]int)
+	for id := range nodes {
+		for other, d := range nodes.distances(id) {
+			// Count every pair only from its lower id.
+			if other > id {
+				hist[d]++
+			}
+		}
+	}
+	return hist
+}
+
+// WienerPolarity returns the Wiener polarity index of the graph, the number of
+// unordered node pairs whose shortest path has length exactly 3.
+func (g *Graph) WienerPolarity() int {
+	return g.nodes.distanceHistogram()[3]
+}
+
+// SetDistance returns the length of the shortest path from any of the sources
+// to any of the sinks, found by a single BFS started from all sources at once.
+// Unknown names are ignored. It returns -1 if no sink is reachable from any
+// source.
+func (g *Graph) SetDistance(sources, sinks []string) int {
+	sinkSet := g.lookupSet(sinks)
+
+	dist := make(map[nodeID]int)
+	var queue []nodeID
+	for id := range g.lookupSet(sources) {
+		dist[id] = 0
+		queue = append(queue, id)
+	}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if sinkSet[id] {
+			return dist[id]
+		}
+		for adj := range g.nodes[id].adj {
+			if _, seen := dist[adj]; !seen {
+				dist[adj] = dist[id] + 1
+				queue = append(queue, adj)
+			}
+		}
+	}
+	return -1
+}
+
+// wienerIndex returns the Wiener index of the graph, the sum of the shortest
+// path lengths over all unordered node pairs. It returns false if the graph is
+// disconnected, in which case the index is infinite.
+func (nodes nodes) wienerIndex() (int, bool) {
+	var sum int
+	for id := range nodes {
+		dist := nodes.distances(id)
+		if len(dist) != len(nodes) {
+			return 0, false
+		}
+		for other, d := range dist {
+			if other > id {
+				sum += d
+			}
+		}
+	}
+	return sum, true
+}
+
+// ClosenessVitality returns for every node how much the Wiener index of the
+// graph grows when the node is removed, i.e. WienerIndex(graph) minus
+// WienerIndex(graph without the node). The Wiener index is infinite for a
+// disconnected graph, so a node reports +Inf whenever the graph is
+// disconnected either with or without it. Cut vertices therefore stand out
+// with +Inf.
+func (g *Graph) ClosenessVitality() map[string]float64 {
+	names := g.symbolTable.names()
+	vitality := make(map[string]float64, len(g.nodes))
+
+	full, connected := g.nodes.wienerIndex()
+	for id := range g.nodes {
+		name := string(names[id])
+		if !connected {
+			vitality[name] = math.Inf(1)
+			continue
+		}
+		without := g.nodes.clone()
+		without.removeNode(id)
+		w, ok := without.wienerIndex()
+		if !ok {
+			vitality[name] = math.Inf(1)
+			continue
+		}
+		vitality[name] = float64(full - w)
+	}
+	return vitality
+}
+
+// MetricDimension returns a smallest set of landmark nodes that resolves the
+// graph, i.e. every node has a different vector of distances to the
+// landmarks, together with its size. Landmark sets are tried in increasing
+// size, so the running time is exponential and it is only meant for small
+// graphs. Nodes in another component than a landmark are at distance -1 from
+// it.
+func (g *Graph) MetricDimension() ([]string, int) {
+	ids := g.nodes.ids()
+	dist := make(map[nodeID]map[nodeID]int, len(ids))
+	for _, id := range ids {
+		dist[id] = g.nodes.distances(id)
+	}
+
+	resolves := func(landmarks []nodeID) bool {
+		seen := make(map[string]bool, len(ids))
+		for _, id := range ids {
+			key := make([]byte, 0, 4*len(landmarks))
+			for _, l := range landmarks {
+				d, ok := dist[l][id]
+				if !ok {
+					d = -1
+				}
+				key = append(key, byte(d>>24), byte(d>>16), byte(d>>8), byte(d))
+			}
+			if seen[string(key)] {
+				return false
+			}
+			seen[string(key)] = true
+		}
+		return true
+	}
+
+	for k := 0; k <= len(ids); k++ {
+		var found []nodeID
+		combinations(ids, k, func(set []nodeID) bool {
+			if resolves(set) {
+				found = append([]nodeID{}, set...)
+				return false
+			}
+			return true
+		})
+		if found != nil {
+			return g.symbolTable.toStrings(found), k
+		}
+	}
+	return nil, 0
+}
+
+// combinations calls f with every subset of k ids, in lexicographic order of
+// their positions in ids, until f returns false. The slice passed to f is
+// reused between calls.
+func combinations(ids []nodeID, k int, f func([]nodeID) bool) {
+	set := make([]nodeID, k)
+	var choose func(start, i int) bool
+	choose = func(start, i int) bool {
+		if i == k {
+			return f(set)
+		}
+		for j := start; j <= len(ids)-(k-i); j++ {
+			set[i] = ids[j]
+			if !choose(j+1, i+1) {
+				return false
+			}
+		}
+		return true
+	}
+	choose(0, 0)
+}
+
+// LandmarkEmbedding returns for every node its vector of distances to the
+// given landmarks, in the order of the landmarks. A landmark that is unknown
+// or can't be reached from the node is at distance -1. The distance between
+// two nodes is bounded by their embeddings through the triangle inequality,
+// which makes the embedding usable as an approximate distance oracle.
+func (g *Graph) LandmarkEmbedding(landmarks []string) map[string][]int {
+	names := g.symbolTable.names()
+	emb := make(map[string][]int, len(g.nodes))
+	for id := range g.nodes {
+		v := make([]int, len(landmarks))
+		for i := range v {
+			v[i] = -1
+		}
+		emb[string(names[id])] = v
+	}
+
+	for i, l := range landmarks {
+		id, err := g.find(l)
+		if err != nil {
+			continue
+		}
+		for other, d := range g.nodes.distances(id) {
+			emb[string(names[other])][i] = d
+		}
+	}
+	return emb
+}
+
+// CharacteristicPathLength returns the mean shortest path length over all
+// unordered pairs of distinct nodes that can reach each other. Pairs in
+// different components are left out instead of making the mean infinite. It
+// is 0 when no two nodes can reach each other.
+func (g *Graph) CharacteristicPathLength() float64 {
+	return g.nodes.characteristicPathLength()
+}
+
+// characteristicPathLength returns the mean length of all finite shortest
+// paths between distinct nodes.
+func (nodes nodes) characteristicPathLength() float64 {
+	var sum, pairs int
+	for d, count := range nodes.distanceHistogram() {
+		sum += d * count
+		pairs += count
+	}
+	if pairs == 0 {
+		return 0
+	}
+	return float64(sum) / float64(pairs)
+}
+
+// BoundedDiameter returns the diameter of the graph if it is at most maxHops,
+// and maxHops otherwise. Every BFS stops after maxHops levels, which makes it
+// much cheaper than the full diameter on graphs known to be shallow.
+func (g *Graph) BoundedDiameter(maxHops int) int {
+	var diameter int
+	for id := range g.nodes {
+		seen := map[nodeID]bool{id: true}
+		frontier := []nodeID{id}
+		depth := 0
+		for depth < maxHops && len(frontier) > 0 {
+			var next []nodeID
+			for _, n := range frontier {
+				for adj := range g.nodes[n].adj {
+					if !seen[adj] {
+						seen[adj] = true
+						next = append(next, adj)
+					}
+				}
+			}
+			if len(next) == 0 {
+				break
+			}
+			depth++
+			frontier = next
+		}
+		if depth > diameter {
+			diameter = depth
+		}
+	}
+	return diameter
+}
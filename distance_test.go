@@ -0,0 +1,387 @@
+package diameter
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestWienerPolarity(t *testing.T) {
+
+	tests := []struct {
+		name        string
+		edgeList    edgeList
+		expPolarity int
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:     "3 in line",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}},
+		},
+		{
+			name:        "4 in line",
+			edgeList:    edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}},
+			expPolarity: 1,
+		},
+		{
+			name:        "5 in line",
+			edgeList:    edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}, {"d", "e"}},
+			expPolarity: 2,
+		},
+		{
+			name:     "Square",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}, {"a", "d"}},
+		},
+		{
+			name:        "two 4 in lines",
+			edgeList:    edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}, {"e", "f"}, {"f", "g"}, {"g", "h"}},
+			expPolarity: 2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := New()
+			test.edgeList.build(g)
+			p := g.WienerPolarity()
+			if p != test.expPolarity {
+				t.Errorf("Wiener polarity not as expected. Have %d, expected %d", p, test.expPolarity)
+			}
+		})
+	}
+}
+
+func TestSetDistance(t *testing.T) {
+	el := edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}, {"x", "y"}}
+
+	tests := []struct {
+		name    string
+		sources []string
+		sinks   []string
+		expDist int
+	}{
+		{
+			name:    "empty",
+			expDist: -1,
+		},
+		{
+			name:    "single pair",
+			sources: []string{"a"},
+			sinks:   []string{"d"},
+			expDist: 3,
+		},
+		{
+			name:    "nearest sink",
+			sources: []string{"a"},
+			sinks:   []string{"c", "d"},
+			expDist: 2,
+		},
+		{
+			name:    "nearest source",
+			sources: []string{"a", "c"},
+			sinks:   []string{"d"},
+			expDist: 1,
+		},
+		{
+			name:    "overlap",
+			sources: []string{"a", "b"},
+			sinks:   []string{"b", "d"},
+		},
+		{
+			name:    "unreachable",
+			sources: []string{"a"},
+			sinks:   []string{"x", "unknown"},
+			expDist: -1,
+		},
+	}
+
+	g := New()
+	el.build(g)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d := g.SetDistance(test.sources, test.sinks)
+			if d != test.expDist {
+				t.Errorf("Distance not as expected. Have %d, expected %d", d, test.expDist)
+			}
+		})
+	}
+}
+
+func TestClosenessVitality(t *testing.T) {
+	inf := math.Inf(1)
+
+	tests := []struct {
+		name     string
+		edgeList edgeList
+		exp      map[string]float64
+	}{
+		{
+			name: "empty",
+			exp:  map[string]float64{},
+		},
+		{
+			name:     "4 in line",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}},
+			exp:      map[string]float64{"a": 6, "b": inf, "c": inf, "d": 6},
+		},
+		{
+			name:     "Triangle",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"a", "c"}},
+			exp:      map[string]float64{"a": 2, "b": 2, "c": 2},
+		},
+		{
+			name:     "Square",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}, {"a", "d"}},
+			exp:      map[string]float64{"a": 4, "b": 4, "c": 4, "d": 4},
+		},
+		{
+			name:     "disconnected",
+			edgeList: edgeList{{"a", "b"}, {"c", "d"}},
+			exp:      map[string]float64{"a": inf, "b": inf, "c": inf, "d": inf},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := New()
+			test.edgeList.build(g)
+			v := g.ClosenessVitality()
+			if len(v) != len(test.exp) {
+				t.Errorf("Number of nodes not as expected. Have %d, expected %d", len(v), len(test.exp))
+			}
+			for name, exp := range test.exp {
+				if v[name] != exp {
+					t.Errorf("Closeness vitality of %s not as expected. Have %f, expected %f", name, v[name], exp)
+				}
+			}
+		})
+	}
+}
+
+func TestMetricDimension(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		edgeList edgeList
+		expDim   int
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:     "1 edge",
+			edgeList: edgeList{{"a", "b"}},
+			expDim:   1,
+		},
+		{
+			name:     "4 in line",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}},
+			expDim:   1,
+		},
+		{
+			name:     "Triangle",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"a", "c"}},
+			expDim:   2,
+		},
+		{
+			name:     "Square",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}, {"a", "d"}},
+			expDim:   2,
+		},
+		{
+			name:     "K4",
+			edgeList: edgeList{{"a", "b"}, {"a", "c"}, {"a", "d"}, {"b", "c"}, {"b", "d"}, {"c", "d"}},
+			expDim:   3,
+		},
+		{
+			name:     "Star",
+			edgeList: edgeList{{"h", "a"}, {"h", "b"}, {"h", "c"}, {"h", "d"}},
+			expDim:   3,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := New()
+			test.edgeList.build(g)
+			landmarks, dim := g.MetricDimension()
+			if dim != test.expDim || len(landmarks) != dim {
+				t.Errorf("Metric dimension not as expected. Have %d (%v), expected %d", dim, landmarks, test.expDim)
+			}
+
+			// Every node must have a unique distance vector.
+			seen := make(map[string]nodeName)
+			for name, id := range g.symbolTable.byName {
+				var v []int
+				for _, l := range landmarks {
+					v = append(v, g.nodes.distances(g.symbolTable.byName[nodeName(l)])[id])
+				}
+				key := fmt.Sprint(v)
+				if other, ok := seen[key]; ok {
+					t.Errorf("Nodes %s and %s are not resolved by %v", name, other, landmarks)
+				}
+				seen[key] = name
+			}
+		})
+	}
+
+	t.Run("path endpoint", func(t *testing.T) {
+		g := New()
+		edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}}.build(g)
+		landmarks, _ := g.MetricDimension()
+		if len(landmarks) != 1 || (landmarks[0] != "a" && landmarks[0] != "d") {
+			t.Errorf("Expected an endpoint of the path as landmark, have %v", landmarks)
+		}
+	})
+}
+
+func TestLandmarkEmbedding(t *testing.T) {
+	el := edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}, {"x", "y"}}
+
+	tests := []struct {
+		name      string
+		landmarks []string
+		exp       map[string][]int
+	}{
+		{
+			name: "no landmarks",
+			exp:  map[string][]int{"a": {}, "b": {}, "c": {}, "d": {}, "x": {}, "y": {}},
+		},
+		{
+			name:      "endpoints",
+			landmarks: []string{"a", "d"},
+			exp: map[string][]int{
+				"a": {0, 3}, "b": {1, 2}, "c": {2, 1}, "d": {3, 0},
+				"x": {-1, -1}, "y": {-1, -1},
+			},
+		},
+		{
+			name:      "unknown and other component",
+			landmarks: []string{"unknown", "x"},
+			exp: map[string][]int{
+				"a": {-1, -1}, "b": {-1, -1}, "c": {-1, -1}, "d": {-1, -1},
+				"x": {-1, 0}, "y": {-1, 1},
+			},
+		},
+	}
+
+	g := New()
+	el.build(g)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			emb := g.LandmarkEmbedding(test.landmarks)
+			if len(emb) != len(test.exp) {
+				t.Errorf("Number of nodes not as expected. Have %d, expected %d", len(emb), len(test.exp))
+			}
+			for name, exp := range test.exp {
+				if fmt.Sprint(emb[name]) != fmt.Sprint(exp) {
+					t.Errorf("Embedding of %s not as expected. Have %v, expected %v", name, emb[name], exp)
+				}
+			}
+		})
+	}
+}
+
+func TestCharacteristicPathLength(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		edgeList edgeList
+		exp      float64
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:     "self-loop",
+			edgeList: edgeList{{"a", "a"}},
+		},
+		{
+			name:     "1 edge",
+			edgeList: edgeList{{"a", "b"}},
+			exp:      1,
+		},
+		{
+			name:     "two disjoint edges",
+			edgeList: edgeList{{"a", "b"}, {"c", "d"}},
+			exp:      1,
+		},
+		{
+			name:     "4 in line",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}},
+			exp:      10.0 / 6.0,
+		},
+		{
+			name:     "Square",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}, {"a", "d"}},
+			exp:      8.0 / 6.0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := New()
+			test.edgeList.build(g)
+			l := g.CharacteristicPathLength()
+			if l != test.exp {
+				t.Errorf("Characteristic path length not as expected. Have %f, expected %f", l, test.exp)
+			}
+		})
+	}
+}
+
+func TestBoundedDiameter(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		edgeList edgeList
+		maxHops  int
+		exp      int
+	}{
+		{
+			name:    "empty",
+			maxHops: 5,
+		},
+		{
+			name:     "4 in line capped",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}},
+			maxHops:  2,
+			exp:      2,
+		},
+		{
+			name:     "4 in line exact",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}},
+			maxHops:  5,
+			exp:      3,
+		},
+		{
+			name:     "4 in line at bound",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}},
+			maxHops:  3,
+			exp:      3,
+		},
+		{
+			name:     "no hops",
+			edgeList: edgeList{{"a", "b"}},
+		},
+		{
+			name:     "2 loops",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"c", "a"}, {"c", "d"}, {"d", "e"}, {"e", "c"}},
+			maxHops:  10,
+			exp:      2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := New()
+			test.edgeList.build(g)
+			d := g.BoundedDiameter(test.maxHops)
+			if d != test.exp {
+				t.Errorf("Bounded diameter not as expected. Have %d, expected %d", d, test.exp)
+			}
+		})
+	}
+}
@@ -0,0 +1,282 @@
+package diameter
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteDOT writes the graph in the DOT language of Graphviz as an undirected
+// graph: a statement for every node in the order they were added to the
+// graph, followed by a statement for every edge sorted by the order of its
+// nodes. All names are quoted.
+func (g *Graph) WriteDOT(w io.Writer) error {
+	names := g.symbolTable.names()
+	ids := g.nodes.ids()
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "graph {")
+	for _, id := range ids {
+		fmt.Fprintf(bw, "\t%s;\n", dotQuote(names[id]))
+	}
+	for _, id := range ids {
+		for _, adj := range g.nodes.neighbors(id) {
+			if adj >= id {
+				fmt.Fprintf(bw, "\t%s -- %s;\n", dotQuote(names[id]), dotQuote(names[adj]))
+			}
+		}
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+// dotQuote returns the name as a quoted DOT string. Backslashes are escaped
+// first, so that a name ending in one doesn't escape the closing quote.
+func dotQuote(name nodeName) string {
+	escaped := strings.ReplaceAll(string(name), `\`, `\\`)
+	return `"` + strings.ReplaceAll(escaped, `"`, `\"`) + `"`
+}
+
+// ReadDOT reads a graph in the DOT language of Graphviz. It understands node
+// statements, edge statements with chains of nodes like a -- b -- c, and
+// quoted and unquoted names, in which \" and \\ stand for a quote and a
+// backslash. The graph is undirected, so a digraph and its -> edges are read
+// as edges. Attributes, ports and comments are ignored.
+// Subgraphs are not supported and return an error, as do syntax errors.
+func ReadDOT(r io.Reader) (*Graph, error) {
+	src, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := dotTokens(string(src))
+	if err != nil {
+		return nil, err
+	}
+	p := &dotParser{tokens: tokens}
+	g := New()
+	if err := p.parse(g); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// dotToken is a single token of the DOT language. Names and the keywords are
+// read as ids, quoted names have quoted set.
+type dotToken struct {
+	text   string
+	id     bool
+	quoted bool
+	line   int
+}
+
+// dotTokens splits the DOT source into tokens, dropping whitespace and
+// comments.
+func dotTokens(src string) ([]dotToken, error) {
+	var tokens []dotToken
+	line := 1
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == '\n':
+			line++
+			i++
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+		case c == '#' && (i == 0 || src[i-1] == '\n'), strings.HasPrefix(src[i:], "//"):
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("diameter: line %d: unterminated comment", line)
+			}
+			line += strings.Count(src[i:i+2+end], "\n")
+			i += end + 4
+		case strings.HasPrefix(src[i:], "--"), strings.HasPrefix(src[i:], "->"):
+			tokens = append(tokens, dotToken{text: src[i : i+2], line: line})
+			i += 2
+		case strings.IndexByte("{}[];,=:", c) >= 0:
+			tokens = append(tokens, dotToken{text: src[i : i+1], line: line})
+			i++
+		case c == '"':
+			start := line
+			var b strings.Builder
+			for i++; ; i++ {
+				if i >= len(src) {
+					return nil, fmt.Errorf("diameter: line %d: unterminated string", start)
+				}
+				if src[i] == '"' {
+					break
+				}
+				if src[i] == '\\' && i+1 < len(src) && (src[i+1] == '"' || src[i+1] == '\\') {
+					i++
+				}
+				if src[i] == '\n' {
+					line++
+				}
+				b.WriteByte(src[i])
+			}
+			i++
+			tokens = append(tokens, dotToken{text: b.String(), id: true, quoted: true, line: start})
+		case isDOTIDByte(c) || c == '-':
+			j := i + 1
+			for j < len(src) && isDOTIDByte(src[j]) {
+				j++
+			}
+			tokens = append(tokens, dotToken{text: src[i:j], id: true, line: line})
+			i = j
+		default:
+			return nil, fmt.Errorf("diameter: line %d: unexpected character %q", line, c)
+		}
+	}
+	return tokens, nil
+}
+
+// isDOTIDByte reports whether c may be part of an unquoted name or number.
+func isDOTIDByte(c byte) bool {
+	return c == '_' || c == '.' || c >= 0x80 ||
+		'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9'
+}
+
+// dotParser reads the statements of a DOT graph from its tokens.
+type dotParser struct {
+	tokens []dotToken
+	pos    int
+}
+
+// peek returns the next token without consuming it, or an empty token at the
+// end of the input.
+func (p *dotParser) peek() dotToken {
+	if p.pos >= len(p.tokens) {
+		return dotToken{}
+	}
+	return p.tokens[p.pos]
+}
+
+// next consumes the next token and returns it.
+func (p *dotParser) next() dotToken {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+// keyword reports whether t is the unquoted keyword kw, which is case
+// insensitive.
+func (t dotToken) keyword(kw string) bool {
+	return t.id && !t.quoted && strings.EqualFold(t.text, kw)
+}
+
+// errorf returns an error for the position of token t.
+func (p *dotParser) errorf(t dotToken, format string, args ...interface{}) error {
+	if t.line == 0 {
+		return fmt.Errorf("diameter: unexpected end of input: "+format, args...)
+	}
+	return fmt.Errorf("diameter: line %d: "+format, append([]interface{}{t.line}, args...)...)
+}
+
+// parse adds the nodes and edges of the graph statement to g.
+func (p *dotParser) parse(g *Graph) error {
+	if p.peek().keyword("strict") {
+		p.next()
+	}
+	if t := p.next(); !t.keyword("graph") && !t.keyword("digraph") {
+		return p.errorf(t, "expected graph, have %q", t.text)
+	}
+	if p.peek().id {
+		p.next()
+	}
+	if t := p.next(); t.text != "{" || t.id {
+		return p.errorf(t, "expected {, have %q", t.text)
+	}
+
+	for {
+		t := p.next()
+		switch {
+		case t.line == 0:
+			return p.errorf(t, "expected }")
+		case t.text == "}" && !t.id:
+			if p.pos < len(p.tokens) {
+				return p.errorf(p.peek(), "unexpected %q after the graph", p.peek().text)
+			}
+			return nil
+		case t.text == ";" && !t.id:
+		case t.keyword("subgraph"), t.text == "{" && !t.id:
+			return p.errorf(t, "subgraphs are not supported")
+		case (t.keyword("graph") || t.keyword("node") || t.keyword("edge")) && p.peek().text == "[":
+			if err := p.skipAttributes(); err != nil {
+				return err
+			}
+		case t.id && p.peek().text == "=" && !p.peek().id:
+			p.next()
+			if v := p.next(); !v.id {
+				return p.errorf(v, "expected a value, have %q", v.text)
+			}
+		case t.id:
+			if err := p.nodeOrEdges(g, t); err != nil {
+				return err
+			}
+		default:
+			return p.errorf(t, "unexpected %q", t.text)
+		}
+	}
+}
+
+// nodeOrEdges reads a node statement or an edge statement starting with the
+// node named by t, and adds it to g.
+func (p *dotParser) nodeOrEdges(g *Graph, t dotToken) error {
+	chain := []string{t.text}
+	p.skipPort()
+	for op := p.peek(); !op.id && (op.text == "--" || op.text == "->"); op = p.peek() {
+		p.next()
+		n := p.next()
+		if n.keyword("subgraph") || n.text == "{" && !n.id {
+			return p.errorf(n, "subgraphs are not supported")
+		}
+		if !n.id {
+			return p.errorf(n, "expected a node, have %q", n.text)
+		}
+		chain = append(chain, n.text)
+		p.skipPort()
+	}
+	if err := p.skipAttributes(); err != nil {
+		return err
+	}
+
+	if len(chain) == 1 {
+		g.AddNode(chain[0])
+	}
+	for i := 1; i < len(chain); i++ {
+		g.AddEdge(chain[i-1], chain[i])
+	}
+	return nil
+}
+
+// skipPort skips the port and compass point after a node name.
+func (p *dotParser) skipPort() {
+	for p.peek().text == ":" && !p.peek().id {
+		p.next()
+		if p.peek().id {
+			p.next()
+		}
+	}
+}
+
+// skipAttributes skips the attribute lists in brackets at the current
+// position, if any.
+func (p *dotParser) skipAttributes() error {
+	for p.peek().text == "[" && !p.peek().id {
+		open := p.next()
+		for {
+			t := p.next()
+			if t.line == 0 {
+				return p.errorf(open, "unterminated attribute list")
+			}
+			if t.text == "]" && !t.id {
+				break
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package diameter
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestWriteDOT(t *testing.T) {
+	g := New()
+	edgeList{{"a", "b"}, {"b", "c"}, {"c", "a"}, {"say \"hi\"", "say \"hi\""}}.build(g)
+	g.AddNode("lonely")
+
+	exp := `graph {
+	"a";
+	"b";
+	"c";
+	"say \"hi\"";
+	"lonely";
+	"a" -- "b";
+	"a" -- "c";
+	"b" -- "c";
+	"say \"hi\"" -- "say \"hi\"";
+}
+`
+	var b bytes.Buffer
+	if err := g.WriteDOT(&b); err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != exp {
+		t.Errorf("Output not as expected. Have\n%s\nexpected\n%s", b.String(), exp)
+	}
+	if err := g.WriteDOT(failingWriter{}); err == nil {
+		t.Errorf("Expected the write error to be returned")
+	}
+}
+
+func TestReadDOT(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		input    string
+		expNodes int
+		expEdges edgeList
+		expErr   bool
+	}{
+		{
+			name:     "triangle",
+			input:    "graph { a -- b; b -- c; c -- a }",
+			expNodes: 3,
+			expEdges: edgeList{{"a", "b"}, {"b", "c"}, {"a", "c"}},
+		},
+		{
+			name: "chains, attributes and comments",
+			input: `# generated
+strict graph G {
+	// settings
+	graph [rankdir=LR];
+	node [shape=box, color="red"]
+	label = "test"
+	/* a
+	   chain */
+	"new york" -- boston -- 42 [weight=2];
+	lonely [label="isolated"];
+	boston:n -- -1.5:port:sw
+}
+`,
+			expNodes: 5,
+			expEdges: edgeList{{"new york", "boston"}, {"boston", "42"}, {"boston", "-1.5"}},
+		},
+		{
+			name:     "digraph",
+			input:    "digraph {\n a -> b\n b -> a\n \"b\" -> \"c\\\"d\"\n}",
+			expNodes: 3,
+			expEdges: edgeList{{"a", "b"}, {"b", "c\"d"}},
+		},
+		{
+			name:     "escapes",
+			input:    `graph { "x\\" -- "y\"z"; "a\nb" }`,
+			expNodes: 3,
+			expEdges: edgeList{{`x\`, `y"z`}},
+		},
+		{
+			name:     "keywords as quoted names",
+			input:    `GRAPH { "node" -- "edge"; "subgraph" }`,
+			expNodes: 3,
+			expEdges: edgeList{{"node", "edge"}},
+		},
+		{
+			name:   "not a graph",
+			input:  "a -- b",
+			expErr: true,
+		},
+		{
+			name:   "missing closing brace",
+			input:  "graph { a -- b",
+			expErr: true,
+		},
+		{
+			name:   "subgraph",
+			input:  "graph { subgraph s { a -- b } }",
+			expErr: true,
+		},
+		{
+			name:   "edge to subgraph",
+			input:  "graph { a -- { b c } }",
+			expErr: true,
+		},
+		{
+			name:   "dangling edge",
+			input:  "graph { a -- ; }",
+			expErr: true,
+		},
+		{
+			name:   "unterminated string",
+			input:  "graph { \"a -- b }",
+			expErr: true,
+		},
+		{
+			name:   "unterminated attributes",
+			input:  "graph { a [color=red }",
+			expErr: true,
+		},
+		{
+			name:   "trailing data",
+			input:  "graph { a } b",
+			expErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g, err := ReadDOT(strings.NewReader(test.input))
+			if (err != nil) != test.expErr {
+				t.Fatalf("Unexpected error %v", err)
+			}
+			if err != nil {
+				return
+			}
+			if len(g.nodes) != test.expNodes {
+				t.Errorf("Number of nodes not as expected. Have %d, expected %d", len(g.nodes), test.expNodes)
+			}
+			if e := len(g.nodes.edges()); e != len(test.expEdges) {
+				t.Errorf("Number of edges not as expected. Have %d, expected %d", e, len(test.expEdges))
+			}
+			for _, e := range test.expEdges {
+				if !g.nodes.adjacent(g.symbolTable.byName[e.a], g.symbolTable.byName[e.b]) {
+					t.Errorf("Edge %s-%s missing", e.a, e.b)
+				}
+			}
+		})
+	}
+}
+
+func TestDOTRoundTrip(t *testing.T) {
+	g := New()
+	edgeList{{"a", "b"}, {"b", "c"}, {"c", "a"}, {"c", "d"}, {"d", "e"}, {"e", "c"}, {"x \"y\"", "x \"y\""}}.build(g)
+	edgeList{{`x\`, `a\"b`}, {`a\"b`, `\\`}, {`\\`, `"`}}.build(g)
+	g.AddNode("lonely")
+
+	var first bytes.Buffer
+	if err := g.WriteDOT(&first); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := ReadDOT(bytes.NewReader(first.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var second bytes.Buffer
+	if err := loaded.WriteDOT(&second); err != nil {
+		t.Fatal(err)
+	}
+	if first.String() != second.String() {
+		t.Errorf("Round trip not as expected. Have\n%s\nexpected\n%s", second.String(), first.String())
+	}
+	if fmt.Sprint(loaded.symbolTable.names()) != fmt.Sprint(g.symbolTable.names()) {
+		t.Errorf("Names not as expected. Have %q, expected %q", loaded.symbolTable.names(), g.symbolTable.names())
+	}
+	if loaded.Diameter() != g.Diameter() {
+		t.Errorf("Diameter not as expected. Have %d, expected %d", loaded.Diameter(), g.Diameter())
+	}
+}
@@ -0,0 +1,99 @@
+package diameter
+
+import (
+	"fmt"
+	"sort"
+)
+
+// motifNames names the connected graphs on 3 and 4 nodes by their sorted
+// degree sequence, which tells all of them apart.
+var motifNames = map[string]string{
+	"[1 1 2]":   "path",
+	"[2 2 2]":   "triangle",
+	"[1 1 2 2]": "path",
+	"[1 1 1 3]": "star",
+	"[2 2 2 2]": "cycle",
+	"[1 2 2 3]": "tailed-triangle",
+	"[2 2 3 3]": "diamond",
+	"[3 3 3 3]": "complete",
+}
+
+// MotifCounts counts the connected induced subgraphs with size nodes by their
+// isomorphism type. For size 3 the types are "path" and "triangle", for size
+// 4 they are "path", "star", "cycle", "tailed-triangle", "diamond" and
+// "complete". Other sizes are not supported and return nil. All node subsets
+// of the given size are examined, so it is meant for small graphs.
+// Self-loops are ignored.
+func (g *Graph) MotifCounts(size int) map[string]int {
+	if size != 3 && size != 4 {
+		return nil
+	}
+
+	counts := make(map[string]int)
+	combinations(g.nodes.ids(), size, func(set []nodeID) bool {
+		degrees := make([]int, size)
+		for i, a := range set {
+			for _, b := range set {
+				if a != b && g.nodes.adjacent(a, b) {
+					degrees[i]++
+				}
+			}
+		}
+		sort.Ints(degrees)
+		// Unknown sequences belong to disconnected subgraphs.
+		if name, ok := motifNames[fmt.Sprint(degrees)]; ok {
+			counts[name]++
+		}
+		return true
+	})
+	return counts
+}
+
+// ContainsSubgraph reports whether the pattern occurs in the graph, i.e.
+// whether the pattern's nodes can be mapped to distinct nodes of the graph
+// such that every edge of the pattern maps to an edge of the graph. The graph
+// may have additional edges between the mapped nodes. It uses a backtracking
+// search that is exponential in the size of the pattern, so the pattern
+// should be small. The empty pattern is contained in every graph.
+func (g *Graph) ContainsSubgraph(pattern *Graph) bool {
+	// Map the pattern nodes with the most neighbors first, they constrain the
+	// search the most.
+	order := pattern.nodes.ids()
+	sort.SliceStable(order, func(i, j int) bool {
+		return len(pattern.nodes[order[i]].adj) > len(pattern.nodes[order[j]].adj)
+	})
+	hosts := g.nodes.ids()
+
+	mapping := make(map[nodeID]nodeID, len(order))
+	used := make(map[nodeID]bool, len(order))
+	var match func(i int) bool
+	match = func(i int) bool {
+		if i == len(order) {
+			return true
+		}
+		p := pattern.nodes[order[i]]
+		for _, h := range hosts {
+			if used[h] || len(g.nodes[h].adj) < len(p.adj) {
+				continue
+			}
+			mapping[p.id] = h
+			fits := true
+			for adj := range p.adj {
+				if m, ok := mapping[adj]; ok && !g.nodes.adjacent(h, m) {
+					fits = false
+					break
+				}
+			}
+			if fits {
+				used[h] = true
+				if match(i + 1) {
+					return true
+				}
+				used[h] = false
+			}
+			delete(mapping, p.id)
+		}
+		return false
+	}
+	return match(0)
+}
@@ -0,0 +1,139 @@
+package diameter
+
+import "testing"
+
+func TestMotifCounts(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		edgeList edgeList
+		size     int
+		exp      map[string]int
+	}{
+		{
+			name: "unsupported size",
+			size: 5,
+		},
+		{
+			name: "empty",
+			size: 3,
+			exp:  map[string]int{},
+		},
+		{
+			name:     "Triangle",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"a", "c"}},
+			size:     3,
+			exp:      map[string]int{"triangle": 1},
+		},
+		{
+			name:     "4 in line",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}},
+			size:     3,
+			exp:      map[string]int{"path": 2},
+		},
+		{
+			// One triangle, and the paths d-c-a, d-c-b and c-d-e.
+			name:     "Triangle with tail",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"a", "c"}, {"c", "d"}, {"d", "e"}},
+			size:     3,
+			exp:      map[string]int{"triangle": 1, "path": 3},
+		},
+		{
+			name:     "Triangle with tail size 4",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"a", "c"}, {"c", "d"}, {"d", "e"}},
+			size:     4,
+			exp:      map[string]int{"tailed-triangle": 1, "path": 2},
+		},
+		{
+			name:     "K4",
+			edgeList: edgeList{{"a", "b"}, {"a", "c"}, {"a", "d"}, {"b", "c"}, {"b", "d"}, {"c", "d"}},
+			size:     4,
+			exp:      map[string]int{"complete": 1},
+		},
+		{
+			name:     "Star",
+			edgeList: edgeList{{"h", "a"}, {"h", "b"}, {"h", "c"}, {"h", "d"}},
+			size:     4,
+			exp:      map[string]int{"star": 4},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := New()
+			test.edgeList.build(g)
+			counts := g.MotifCounts(test.size)
+			if (counts == nil) != (test.exp == nil) || len(counts) != len(test.exp) {
+				t.Fatalf("Counts not as expected. Have %v, expected %v", counts, test.exp)
+			}
+			for motif, exp := range test.exp {
+				if counts[motif] != exp {
+					t.Errorf("Count of %s not as expected. Have %d, expected %d", motif, counts[motif], exp)
+				}
+			}
+		})
+	}
+}
+
+func TestContainsSubgraph(t *testing.T) {
+	triangle := edgeList{{"x", "y"}, {"y", "z"}, {"z", "x"}}
+	square := edgeList{{"w", "x"}, {"x", "y"}, {"y", "z"}, {"z", "w"}}
+	path3 := edgeList{{"x", "y"}, {"y", "z"}}
+
+	tests := []struct {
+		name     string
+		edgeList edgeList
+		pattern  edgeList
+		exp      bool
+	}{
+		{
+			name:     "empty pattern",
+			edgeList: edgeList{{"a", "b"}},
+			exp:      true,
+		},
+		{
+			name:     "2 loops triangle",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"c", "a"}, {"c", "d"}, {"d", "e"}, {"e", "c"}},
+			pattern:  triangle,
+			exp:      true,
+		},
+		{
+			name:     "4 in line triangle",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}},
+			pattern:  triangle,
+		},
+		{
+			name:     "K4 square",
+			edgeList: edgeList{{"a", "b"}, {"a", "c"}, {"a", "d"}, {"b", "c"}, {"b", "d"}, {"c", "d"}},
+			pattern:  square,
+			exp:      true,
+		},
+		{
+			name:     "Triangle path",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"c", "a"}},
+			pattern:  path3,
+			exp:      true,
+		},
+		{
+			name:     "pattern too large",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}},
+			pattern:  square,
+		},
+		{
+			name:     "2 loops square",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"c", "a"}, {"c", "d"}, {"d", "e"}, {"e", "c"}},
+			pattern:  square,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g, p := New(), New()
+			test.edgeList.build(g)
+			test.pattern.build(p)
+			if c := g.ContainsSubgraph(p); c != test.exp {
+				t.Errorf("Containment not as expected. Have %t, expected %t", c, test.exp)
+			}
+		})
+	}
+}
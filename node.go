@@ -8,53 +8,236 @@
 package diameter
 
 import (
-	"container/list"
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync/atomic"
 )
 
+// ErrMalformedEdge is returned when a line of an edge list doesn't hold
+// exactly two node names.
+var ErrMalformedEdge = errors.New("diameter: malformed edge")
+
+// ErrDisconnected is returned when a computation is only defined for a
+// connected graph.
+var ErrDisconnected = errors.New("diameter: graph is disconnected")
+
+// ErrNodeNotFound is returned when a named node is not in the graph.
+var ErrNodeNotFound = errors.New("diameter: node not found")
+
+// ErrNoPath is returned when there is no path between two nodes.
+var ErrNoPath = errors.New("diameter: no path")
+
 // nodeID is an unique identifier for each node
 type nodeID int32
 
 // nodeName is the name of the node looked up by id from the symbol table.
 type nodeName string
 
-// symbolTable contains the mapping from id to name.
-type symbolTable map[nodeName]nodeID
+// symbolTable contains the mapping from name to id and back.
+type symbolTable struct {
+	byName map[nodeName]nodeID
+	// byID holds the names indexed by id, ids are assigned in order and never
+	// reused. A removed name leaves an empty tombstone.
+	byID []nodeName
+}
+
+// newSymbolTable returns an empty symbol table.
+func newSymbolTable() symbolTable {
+	return symbolTable{byName: make(map[nodeName]nodeID)}
+}
 
 // getID returns the id of the node with name if it exists, otherwise it adds
 // the name to the table and returns it.
-func (s symbolTable) getID(name nodeName) nodeID {
-	id, ok := s[name]
+func (s *symbolTable) getID(name nodeName) nodeID {
+	id, ok := s.byName[name]
 	if !ok {
-		id = nodeID(len(s))
-		s[name] = id
+		id = nodeID(len(s.byID))
+		s.byName[name] = id
+		s.byID = append(s.byID, name)
 	}
 	return id
 }
 
+// remove removes the name from the table, leaving a tombstone for its id. The
+// id is not reused, adding the name again assigns it a new one.
+func (s *symbolTable) remove(name nodeName) {
+	id, ok := s.byName[name]
+	if !ok {
+		return
+	}
+	delete(s.byName, name)
+	s.byID[id] = ""
+}
+
+// lookup returns the id of the node with name and whether it exists, without
+// adding it to the table.
+func (s symbolTable) lookup(name nodeName) (nodeID, bool) {
+	id, ok := s.byName[name]
+	return id, ok
+}
+
+// names returns the node names indexed by id. The slice is shared with the
+// table and must not be modified.
+func (s symbolTable) names() []nodeName {
+	return s.byID
+}
+
+// toStrings translates a list of node ids into their names.
+func (s symbolTable) toStrings(ids []nodeID) []string {
+	res := make([]string, len(ids))
+	for i, id := range ids {
+		res[i] = string(s.byID[id])
+	}
+	return res
+}
+
 // Graph is the complete graph containing the lookup table for node names and
 // the actual nodes graph.
 type Graph struct {
 	symbolTable
 	nodes
+
+	// times holds the sorted timestamps of the timed edges.
+	times map[nodePair][]int64
+	// weights holds the weights of the weighted edges, other edges weigh 1.
+	weights map[nodePair]float64
+
+	// tracking is set by TrackDiameter, cachedDiameter then holds the
+	// diameter or -1 if it has to be computed again. It is only accessed
+	// atomically, as concurrent readers of the graph may fill it.
+	tracking       bool
+	cachedDiameter int64
 }
 
 // New returns a new graph.
 func New() *Graph {
 	return &Graph{
-		symbolTable: make(symbolTable),
+		symbolTable: newSymbolTable(),
 		nodes:       make(nodes),
+		times:       make(map[nodePair][]int64),
+		weights:     make(map[nodePair]float64),
+	}
+}
+
+// AddNode adds the node with name to the graph without any connections, if it
+// isn't present yet.
+func (g *Graph) AddNode(name string) {
+	// A node without connections doesn't change the diameter.
+	g.nodes.get(g.symbolTable.getID(nodeName(name)))
+}
+
+// find returns the id of the named node, or an error wrapping ErrNodeNotFound
+// if the graph doesn't contain it.
+func (g *Graph) find(name string) (nodeID, error) {
+	id, ok := g.symbolTable.lookup(nodeName(name))
+	if _, exists := g.nodes[id]; !ok || !exists {
+		return 0, fmt.Errorf("%q: %w", name, ErrNodeNotFound)
+	}
+	return id, nil
+}
+
+// NumNodes returns the number of nodes in the graph.
+func (g *Graph) NumNodes() int {
+	return len(g.nodes)
+}
+
+// NumEdges returns the number of edges in the graph. A self-loop counts as
+// one edge.
+func (g *Graph) NumEdges() int {
+	var ends, loops int
+	for id, n := range g.nodes {
+		ends += len(n.adj)
+		if _, ok := n.adj[id]; ok {
+			loops++
+		}
 	}
+	// Every other edge is present at both of its ends.
+	return (ends-loops)/2 + loops
 }
 
-// addEdge adds a connection between node a and b identified by their name.
+// LoadEdges adds the edges read from r to the graph. Every line holds the
+// names of the two nodes of an edge separated by whitespace, blank lines are
+// skipped. It returns the number of edges added. Reading stops at the first
+// line that doesn't hold exactly two names, with an error wrapping
+// ErrMalformedEdge; the edges before it remain added.
+func (g *Graph) LoadEdges(r io.Reader) (int, error) {
+	var n int
+	s := bufio.NewScanner(r)
+	for line := 1; s.Scan(); line++ {
+		edge := strings.Fields(s.Text())
+		if len(edge) == 0 { // Skip empty lines
+			continue
+		}
+		if len(edge) != 2 {
+			return n, fmt.Errorf("line %d: %q: %w", line, s.Text(), ErrMalformedEdge)
+		}
+		g.AddEdge(edge[0], edge[1])
+		n++
+	}
+	return n, s.Err()
+}
+
+// LoadEdgeList returns a new graph with the edges read from r in the format of
+// LoadEdges.
+func LoadEdgeList(r io.Reader) (*Graph, error) {
+	g := New()
+	if _, err := g.LoadEdges(r); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// AddEdge adds a connection between node a and b identified by their name.
 // It retrieves the nodes from the lookup table to get ids.
-func (g *Graph) addEdge(a, b nodeName) {
-	aid := g.symbolTable.getID(a)
-	bid := g.symbolTable.getID(b)
+func (g *Graph) AddEdge(a, b string) {
+	aid := g.symbolTable.getID(nodeName(a))
+	bid := g.symbolTable.getID(nodeName(b))
 
+	if !g.nodes.adjacent(aid, bid) {
+		atomic.StoreInt64(&g.cachedDiameter, -1)
+	}
 	g.nodes.addEdge(aid, bid)
 }
 
+// RemoveEdge removes the connection between node a and b, including its
+// timestamps and weight. The nodes remain in the graph. Nothing happens if
+// there is no such edge.
+func (g *Graph) RemoveEdge(a, b string) {
+	aid, aerr := g.find(a)
+	bid, berr := g.find(b)
+	if aerr != nil || berr != nil || !g.nodes.adjacent(aid, bid) {
+		return
+	}
+	g.nodes.removeEdge(aid, bid)
+	delete(g.times, newNodePair(aid, bid))
+	delete(g.weights, newNodePair(aid, bid))
+	atomic.StoreInt64(&g.cachedDiameter, -1)
+}
+
+// RemoveNode removes the named node and all its connections from the graph.
+// Nothing happens if there is no such node. Ids are never reused, so nodes
+// stay in the order they were added and a node added again goes last. The
+// flip side is that the symbol table, and the BFS buffers sized by the
+// largest id, grow with the number of nodes ever added, not the number
+// currently in the graph.
+func (g *Graph) RemoveNode(name string) {
+	id, err := g.find(name)
+	if err != nil {
+		return
+	}
+	for adj := range g.nodes[id].adj {
+		delete(g.times, newNodePair(id, adj))
+		delete(g.weights, newNodePair(id, adj))
+		atomic.StoreInt64(&g.cachedDiameter, -1)
+	}
+	g.nodes.removeNode(id)
+	g.symbolTable.remove(nodeName(name))
+}
+
 // node represents one node in the graph, identified by it's id.
 // A node knows about all adjacent nodes.
 type node struct {
@@ -86,6 +269,107 @@ func (nodes nodes) get(id nodeID) *node {
 	return n
 }
 
+// ids returns the ids of all nodes in the graph in ascending order.
+func (nodes nodes) ids() []nodeID {
+	ids := make([]nodeID, 0, len(nodes))
+	for id := range nodes {
+		ids = append(ids, id)
+	}
+	sortIDs(ids)
+	return ids
+}
+
+// sortIDs sorts a list of node ids in ascending order.
+func sortIDs(ids []nodeID) {
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+}
+
+// neighbors returns the ids of the adjacent nodes of the node in ascending
+// order.
+func (nodes nodes) neighbors(id nodeID) []nodeID {
+	adj := make([]nodeID, 0, len(nodes[id].adj))
+	for other := range nodes[id].adj {
+		adj = append(adj, other)
+	}
+	sortIDs(adj)
+	return adj
+}
+
+// adjacent reports whether there is an edge between node a and b.
+func (nodes nodes) adjacent(a, b nodeID) bool {
+	n, ok := nodes[a]
+	if !ok {
+		return false
+	}
+	_, ok = n.adj[b]
+	return ok
+}
+
+// degree returns the number of neighbors of the node, not counting the node
+// itself when it has a self-loop.
+func (nodes nodes) degree(id nodeID) int {
+	n := nodes[id]
+	if _, loop := n.adj[id]; loop {
+		return len(n.adj) - 1
+	}
+	return len(n.adj)
+}
+
+// clone returns a deep copy of the nodes.
+func (nodes nodes) clone() nodes {
+	c := make(map[nodeID]*node, len(nodes))
+	for id := range nodes {
+		c[id] = &node{
+			id:  id,
+			adj: make(map[nodeID]*node),
+		}
+	}
+	for id, n := range nodes {
+		for adj := range n.adj {
+			c[id].adj[adj] = c[adj]
+		}
+	}
+	return c
+}
+
+// removeEdge removes the connection between node a and b.
+func (nodes nodes) removeEdge(a, b nodeID) {
+	if n, ok := nodes[a]; ok {
+		delete(n.adj, b)
+	}
+	if n, ok := nodes[b]; ok {
+		delete(n.adj, a)
+	}
+}
+
+// removeNode removes the node and all its connections.
+func (nodes nodes) removeNode(id nodeID) {
+	n, ok := nodes[id]
+	if !ok {
+		return
+	}
+	for adj := range n.adj {
+		delete(nodes[adj].adj, id)
+	}
+	delete(nodes, id)
+}
+
+// subgraph returns a new graph made of the given nodes, which must be a subset
+// of the graph's nodes. Node names carry over from the graph.
+func (g *Graph) subgraph(sub nodes) *Graph {
+	s := New()
+	names := g.symbolTable.names()
+	for _, e := range sub.edges() {
+		s.AddEdge(string(names[e[0]]), string(names[e[1]]))
+	}
+	for _, id := range sub.ids() {
+		if sub.adjacent(id, id) {
+			s.AddEdge(string(names[id]), string(names[id]))
+		}
+	}
+	return s
+}
+
 // addEdge adds a connection between node a and b identified by their id.
 // it adds retrieves/adds the nodes and makes the connection between them, i.e.
 // adding them as adjacent nodes.
@@ -97,51 +381,166 @@ func (nodes *nodes) addEdge(a, b nodeID) {
 	bn.add(an)
 }
 
+// Diameter returns the length of the longest shortest path in the graph. If
+// the graph is disconnected this is the largest diameter of its components,
+// use DiameterInfo to tell the two apart.
+func (g *Graph) Diameter() int {
+	if g.tracking {
+		d := atomic.LoadInt64(&g.cachedDiameter)
+		if d < 0 {
+			d = int64(g.nodes.boundingDiameter())
+			atomic.StoreInt64(&g.cachedDiameter, d)
+		}
+		return int(d)
+	}
+	return g.nodes.diameter()
+}
+
+// DiameterInfo returns the diameter of the graph together with the names of
+// two nodes that far apart and whether the graph is connected. For a
+// disconnected graph the diameter and nodes are those of the component with
+// the largest diameter, as nodes in different components can't reach each
+// other at all. A graph with a single node has diameter 0 with that node as
+// both ends, the empty graph has no ends and counts as connected.
+func (g *Graph) DiameterInfo() (int, []string, bool) {
+	if len(g.nodes) == 0 {
+		return 0, nil, true
+	}
+
+	var diameter int
+	var from, to nodeID
+	connected := true
+	first := true
+	s := g.nodes.newBFSScratch()
+	for _, id := range g.nodes.ids() {
+		far, depth, reached := g.nodes.farthest(s, id)
+		if reached != len(g.nodes) {
+			connected = false
+		}
+		if first || depth > diameter {
+			diameter, from, to = depth, id, far
+			first = false
+		}
+	}
+	return diameter, g.symbolTable.toStrings([]nodeID{from, to}), connected
+}
+
+// Eccentricity returns the length of the longest shortest path from the named
+// node to any other node. Like Diameter it only considers the nodes that can
+// be reached, so in a disconnected graph it is the eccentricity within the
+// node's component. It returns an error wrapping ErrNodeNotFound for an
+// unknown node.
+func (g *Graph) Eccentricity(name string) (int, error) {
+	id, err := g.find(name)
+	if err != nil {
+		return 0, err
+	}
+	return g.nodes.longestShortestPath(g.nodes.newBFSScratch(), id), nil
+}
+
+// DiameterWithProgress computes the diameter like Diameter does, calling
+// report after the BFS from every start node with the number of start nodes
+// done so far and the total number of nodes. report is called from the
+// calling goroutine and the computation waits for it to return.
+func (g *Graph) DiameterWithProgress(report func(done, total int)) int {
+	return g.nodes.diameterWithProgress(report)
+}
+
 // diameter returns the maximum length of a shortest path in the graph.
 func (nodes nodes) diameter() int {
-	var diameter int
+	return nodes.diameterWithProgress(nil)
+}
+
+// diameterWithProgress returns the maximum length of a shortest path in the
+// graph, calling report after every BFS if it isn't nil.
+func (nodes nodes) diameterWithProgress(report func(done, total int)) int {
+	var diameter, done int
+	s := nodes.newBFSScratch()
 	for id := range nodes {
-		df := nodes.longestShortestPath(id)
+		df := nodes.longestShortestPath(s, id)
 		if df > diameter {
 			diameter = df
 		}
+		if report != nil {
+			done++
+			report(done, len(nodes))
+		}
 	}
 	return diameter
 }
 
-// bfsNode is used to keep track of nodes in the Breadth First Search.
-type bfsNode struct {
-	parent *node
-	depth  int
+// bfsScratch holds the buffers of a BFS, so that consecutive BFS don't have
+// to allocate them anew. A scratch must not be shared between goroutines.
+type bfsScratch struct {
+	// depth holds the depth of the reached nodes indexed by id, -1 for the
+	// nodes not reached.
+	depth []int
+	// queue holds the reached nodes in the order they were reached.
+	queue []nodeID
+}
+
+// newBFSScratch returns the buffers for a BFS on the graph.
+func (nodes nodes) newBFSScratch() *bfsScratch {
+	var size nodeID
+	for id := range nodes {
+		if id+1 > size {
+			size = id + 1
+		}
+	}
+	depth := make([]int, size)
+	for i := range depth {
+		depth[i] = -1
+	}
+	return &bfsScratch{
+		depth: depth,
+		queue: make([]nodeID, 0, len(nodes)),
+	}
 }
 
 // longestShortestPath executes the BFS from the start node identified by id.
 // Returns the depth of the BFS which is the longest minimum distance between
 // nodes in the graph.
-func (nodes nodes) longestShortestPath(start nodeID) int {
-	q := list.New()
-
-	bfsData := make(map[nodeID]bfsNode, len(nodes))
-
-	n := nodes.get(start)
-	bfsData[n.id] = bfsNode{parent: n, depth: 0}
-	q.PushBack(n)
+func (nodes nodes) longestShortestPath(s *bfsScratch, start nodeID) int {
+	_, depth, _ := nodes.farthest(s, start)
+	return depth
+}
 
-	for {
-		elt := q.Front()
-		if elt == nil {
-			break
-		}
-		n = q.Remove(elt).(*node)
+// farthest executes the BFS from the start node identified by id using the
+// buffers in s. Returns the last node reached, which is one of the nodes
+// farthest from start, its depth, and the number of nodes reached including
+// start. The graph is only read, so several BFS with their own scratch may
+// run concurrently.
+func (nodes nodes) farthest(s *bfsScratch, start nodeID) (nodeID, int, int) {
+	nodes.bfs(s, start)
+	last := s.queue[len(s.queue)-1]
+	depth := s.depth[last]
+	s.reset()
+	return last, depth, len(s.queue)
+}
 
-		for id, m := range n.adj {
-			bm := bfsData[id]
-			if bm.parent == nil {
-				bfsData[id] = bfsNode{parent: n, depth: bfsData[n.id].depth + 1}
-				q.PushBack(m)
+// bfs executes the BFS from the start node identified by id, leaving the
+// reached nodes in s.queue in the order they were reached and their depths in
+// s.depth. s must be reset before the next BFS.
+func (nodes nodes) bfs(s *bfsScratch, start nodeID) {
+	q := append(s.queue[:0], start)
+	s.depth[start] = 0
+	for head := 0; head < len(q); head++ {
+		id := q[head]
+		for adj := range nodes[id].adj {
+			if s.depth[adj] < 0 {
+				s.depth[adj] = s.depth[id] + 1
+				q = append(q, adj)
 			}
 		}
 	}
+	s.queue = q
+}
 
-	return bfsData[n.id].depth
+// reset marks the nodes reached by the last BFS as not reached again. Only
+// they have to be reset, which keeps consecutive BFS on small components
+// cheap.
+func (s *bfsScratch) reset() {
+	for _, id := range s.queue {
+		s.depth[id] = -1
+	}
 }
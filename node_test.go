@@ -1,7 +1,8 @@
 package diameter
 
 import (
-	"bufio"
+	"errors"
+	"fmt"
 	"os"
 	"strings"
 	"testing"
@@ -12,7 +13,7 @@ type edgeList []edge
 
 func (e edgeList) build(g *Graph) {
 	for _, edge := range e {
-		g.addEdge(edge.a, edge.b)
+		g.AddEdge(string(edge.a), string(edge.b))
 	}
 }
 
@@ -62,7 +63,7 @@ func TestDiameter(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			g := New()
 			test.edgeList.build(g)
-			dia := g.diameter()
+			dia := g.Diameter()
 			if dia != test.expDiameter {
 				t.Errorf("Diameter not as expected. Have %d, expected %d", dia, test.expDiameter)
 			}
@@ -79,27 +80,334 @@ func BenchmarkDiameter(b *testing.B) {
 		return
 	}
 	defer f.Close()
-	s := bufio.NewScanner(f)
-	for s.Scan() {
-		line := s.Text()
-		edge := strings.Fields(line)
-		if len(edge) == 0 { // Skip empty lines
-			continue
-		}
-		if len(edge) != 2 {
-			b.Error("Expected length of edges to be two")
-			return
-		}
-		g.addEdge(nodeName(edge[0]), nodeName(edge[1]))
+	if _, err := g.LoadEdges(f); err != nil {
+		b.Errorf("Could not load edges: %s", err)
+		return
 	}
 
 	b.Run("diameter", func(b *testing.B) {
 		b.ReportAllocs()
 		for i := 0; i < b.N; i++ {
-			d := g.diameter()
+			d := g.Diameter()
+			if d != 8000 {
+				b.Errorf("Expected diameter to be %d was %d", 8000, d)
+			}
+		}
+	})
+
+	b.Run("diameter parallel", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			d := g.DiameterParallel(0)
 			if d != 8000 {
 				b.Errorf("Expected diameter to be %d was %d", 8000, d)
 			}
 		}
 	})
 }
+
+func TestDiameterWithProgress(t *testing.T) {
+	g := New()
+	edgeList{{"a", "b"}, {"b", "c"}, {"c", "a"}, {"c", "d"}, {"d", "e"}, {"e", "c"}, {"e", "f"}}.build(g)
+
+	var calls []int
+	dia := g.DiameterWithProgress(func(done, total int) {
+		if total != len(g.nodes) {
+			t.Errorf("Total not as expected. Have %d, expected %d", total, len(g.nodes))
+		}
+		calls = append(calls, done)
+	})
+
+	if dia != g.Diameter() {
+		t.Errorf("Diameter not as expected. Have %d, expected %d", dia, g.Diameter())
+	}
+	if len(calls) != len(g.nodes) {
+		t.Errorf("Number of progress reports not as expected. Have %d, expected %d", len(calls), len(g.nodes))
+	}
+	for i, done := range calls {
+		if done != i+1 {
+			t.Errorf("Progress report %d not as expected. Have %d, expected %d", i, done, i+1)
+		}
+	}
+}
+
+func TestLoadEdges(t *testing.T) {
+
+	tests := []struct {
+		name        string
+		input       string
+		expEdges    int
+		expErr      bool
+		expDiameter int
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:        "4 in line",
+			input:       "a b\nb c\nc d\n",
+			expEdges:    3,
+			expDiameter: 3,
+		},
+		{
+			name:        "blank lines and spacing",
+			input:       "\n  a \t b\n\n\nb c",
+			expEdges:    2,
+			expDiameter: 2,
+		},
+		{
+			name:        "too few fields",
+			input:       "a b\nc\nd e\n",
+			expEdges:    1,
+			expErr:      true,
+			expDiameter: 1,
+		},
+		{
+			name:   "too many fields",
+			input:  "a b c\n",
+			expErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := New()
+			n, err := g.LoadEdges(strings.NewReader(test.input))
+			if (err != nil) != test.expErr {
+				t.Fatalf("Unexpected error %v", err)
+			}
+			if err != nil && !errors.Is(err, ErrMalformedEdge) {
+				t.Errorf("Expected error to wrap ErrMalformedEdge, have %v", err)
+			}
+			if n != test.expEdges {
+				t.Errorf("Number of edges not as expected. Have %d, expected %d", n, test.expEdges)
+			}
+			if d := g.Diameter(); d != test.expDiameter {
+				t.Errorf("Diameter not as expected. Have %d, expected %d", d, test.expDiameter)
+			}
+		})
+	}
+}
+
+func TestDiameterInfo(t *testing.T) {
+
+	tests := []struct {
+		name         string
+		edgeList     edgeList
+		isolated     []string
+		expDiameter  int
+		expConnected bool
+	}{
+		{
+			name:         "empty",
+			expConnected: true,
+		},
+		{
+			name:         "single node",
+			isolated:     []string{"a"},
+			expConnected: true,
+		},
+		{
+			name:         "4 in line",
+			edgeList:     edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}},
+			expDiameter:  3,
+			expConnected: true,
+		},
+		{
+			name:        "two triangles",
+			edgeList:    edgeList{{"a", "b"}, {"b", "c"}, {"a", "c"}, {"d", "e"}, {"e", "f"}, {"d", "f"}},
+			expDiameter: 1,
+		},
+		{
+			name:        "isolated node",
+			edgeList:    edgeList{{"a", "b"}, {"b", "c"}},
+			isolated:    []string{"x"},
+			expDiameter: 2,
+		},
+		{
+			name:        "path and triangle",
+			edgeList:    edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}, {"x", "y"}, {"y", "z"}, {"x", "z"}},
+			expDiameter: 3,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := New()
+			test.edgeList.build(g)
+			for _, name := range test.isolated {
+				g.AddNode(name)
+			}
+			dia, ends, connected := g.DiameterInfo()
+			if dia != test.expDiameter {
+				t.Errorf("Diameter not as expected. Have %d, expected %d", dia, test.expDiameter)
+			}
+			if dia != g.Diameter() {
+				t.Errorf("Diameter doesn't match Diameter(). Have %d, expected %d", dia, g.Diameter())
+			}
+			if connected != test.expConnected {
+				t.Errorf("Connected not as expected. Have %t, expected %t", connected, test.expConnected)
+			}
+			if len(g.nodes) == 0 {
+				if ends != nil {
+					t.Errorf("Expected no ends, have %v", ends)
+				}
+				return
+			}
+			if len(ends) != 2 {
+				t.Fatalf("Expected two ends, have %v", ends)
+			}
+			a := g.symbolTable.byName[nodeName(ends[0])]
+			if d, ok := g.nodes.distances(a)[g.symbolTable.byName[nodeName(ends[1])]]; !ok || d != dia {
+				t.Errorf("Ends %v are not %d apart", ends, dia)
+			}
+		})
+	}
+}
+
+func TestNumNodesAndEdges(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		edgeList edgeList
+		isolated []string
+		expNodes int
+		expEdges int
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:     "triangle",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"c", "a"}},
+			expNodes: 3,
+			expEdges: 3,
+		},
+		{
+			name:     "duplicate edges",
+			edgeList: edgeList{{"a", "b"}, {"b", "a"}, {"a", "b"}},
+			expNodes: 2,
+			expEdges: 1,
+		},
+		{
+			name:     "self-loop and isolated node",
+			edgeList: edgeList{{"a", "a"}, {"a", "b"}},
+			isolated: []string{"x"},
+			expNodes: 3,
+			expEdges: 2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := New()
+			test.edgeList.build(g)
+			for _, name := range test.isolated {
+				g.AddNode(name)
+			}
+			if n := g.NumNodes(); n != test.expNodes {
+				t.Errorf("Number of nodes not as expected. Have %d, expected %d", n, test.expNodes)
+			}
+			if m := g.NumEdges(); m != test.expEdges {
+				t.Errorf("Number of edges not as expected. Have %d, expected %d", m, test.expEdges)
+			}
+		})
+	}
+}
+
+func TestEccentricity(t *testing.T) {
+	g := New()
+	edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}, {"b", "e"}, {"x", "y"}}.build(g)
+	g.AddNode("lonely")
+
+	exp := map[string]int{"a": 3, "b": 2, "c": 2, "d": 3, "e": 3, "x": 1, "lonely": 0}
+	for name, e := range exp {
+		ecc, err := g.Eccentricity(name)
+		if err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+		if ecc != e {
+			t.Errorf("Eccentricity of %s not as expected. Have %d, expected %d", name, ecc, e)
+		}
+	}
+	if _, err := g.Eccentricity("q"); !errors.Is(err, ErrNodeNotFound) {
+		t.Errorf("Expected error to wrap ErrNodeNotFound, have %v", err)
+	}
+}
+
+func TestLoadEdgeList(t *testing.T) {
+	g, err := LoadEdgeList(strings.NewReader("a b\nb c\n\nc d\n"))
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if d := g.Diameter(); d != 3 {
+		t.Errorf("Diameter not as expected. Have %d, expected %d", d, 3)
+	}
+
+	if _, err := LoadEdgeList(strings.NewReader("a b\nc\n")); !errors.Is(err, ErrMalformedEdge) {
+		t.Errorf("Expected error to wrap ErrMalformedEdge, have %v", err)
+	}
+}
+
+func TestRemoveEdgeAndNode(t *testing.T) {
+	g := New()
+	edgeList{{"a", "b"}, {"b", "c"}, {"c", "a"}, {"c", "d"}}.build(g)
+	g.AddTimedEdge("c", "d", 3)
+	g.AddWeightedEdge("a", "b", 2)
+
+	g.RemoveEdge("b", "a")
+	g.RemoveEdge("a", "d")
+	g.RemoveEdge("a", "q")
+	if n, m := g.NumNodes(), g.NumEdges(); n != 4 || m != 3 {
+		t.Errorf("Graph not as expected. Have %d nodes and %d edges, expected 4 and 3", n, m)
+	}
+	if len(g.weights) != 0 {
+		t.Errorf("Expected the weight of the removed edge to be removed")
+	}
+
+	g.RemoveNode("c")
+	g.RemoveNode("q")
+	if n, m := g.NumNodes(), g.NumEdges(); n != 3 || m != 0 {
+		t.Errorf("Graph not as expected. Have %d nodes and %d edges, expected 3 and 0", n, m)
+	}
+	if len(g.times) != 0 {
+		t.Errorf("Expected the timestamps of the removed edges to be removed")
+	}
+	if _, err := g.Eccentricity("c"); !errors.Is(err, ErrNodeNotFound) {
+		t.Errorf("Expected error to wrap ErrNodeNotFound, have %v", err)
+	}
+	if r := g.ReliabilityEstimate("c", "a", 0, 1, 1); r != 0 {
+		t.Errorf("Reliability of a removed node not as expected. Have %f, expected 0", r)
+	}
+	if g.IsBridge("a", "c") {
+		t.Errorf("Expected an edge to a removed node not to be a bridge")
+	}
+	if _, ok := g.ResistanceDistance("a", "c"); ok {
+		t.Errorf("Expected no resistance distance to a removed node")
+	}
+	if _, ok := g.ShortestPathAvoiding("c", "a", nil); ok {
+		t.Errorf("Expected no path from a removed node")
+	}
+
+	if _, ok := g.symbolTable.lookup("c"); ok {
+		t.Errorf("Expected the removed name to be gone")
+	}
+
+	// Ids are never reused: the node added again gets a new id and goes last,
+	// the others keep theirs.
+	ids := map[string]nodeID{"a": g.symbolTable.byName["a"], "d": g.symbolTable.byName["d"]}
+	g.AddEdge("c", "d")
+	g.AddEdge("a", "c")
+	if id := g.symbolTable.byName["c"]; id != 4 {
+		t.Errorf("Id of the node added again not as expected. Have %d, expected %d", id, 4)
+	}
+	if g.symbolTable.byName["a"] != ids["a"] || g.symbolTable.byName["d"] != ids["d"] {
+		t.Errorf("Expected the ids to be unchanged")
+	}
+	if c, exp := fmt.Sprint(g.ConnectedComponents()), "[[a d c] [b]]"; c != exp {
+		t.Errorf("Components not as expected. Have %s, expected %s", c, exp)
+	}
+	if d := g.Diameter(); d != 2 {
+		t.Errorf("Diameter not as expected. Have %d, expected %d", d, 2)
+	}
+}
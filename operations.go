@@ -0,0 +1,217 @@
+package diameter
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrNameCollision is returned when the name of a super-node is already the
+// name of another node, which would silently merge that node into it.
+var ErrNameCollision = errors.New("diameter: super-node name already taken")
+
+// CartesianProduct returns the Cartesian product of the graph with other. The
+// product has a node "(u,v)" for every node u of the graph and v of other.
+// (u,v) and (u',v') are adjacent if u = u' and v is adjacent to v' in other,
+// or if v = v' and u is adjacent to u' in the graph. The product of two paths
+// is a grid, and the diameter of a product is the sum of the diameters of its
+// factors.
+func (g *Graph) CartesianProduct(other *Graph) *Graph {
+	p := New()
+	gNames := g.symbolTable.names()
+	oNames := other.symbolTable.names()
+	pair := func(u, v nodeID) string {
+		return fmt.Sprintf("(%s,%s)", gNames[u], oNames[v])
+	}
+
+	for _, u := range g.nodes.ids() {
+		for _, v := range other.nodes.ids() {
+			// Nodes made of two isolated nodes have no edges to add them.
+			p.AddNode(pair(u, v))
+			for adj := range other.nodes[v].adj {
+				p.AddEdge(pair(u, v), pair(u, adj))
+			}
+			for adj := range g.nodes[u].adj {
+				p.AddEdge(pair(u, v), pair(adj, v))
+			}
+		}
+	}
+	return p
+}
+
+// quotient returns the graph obtained by merging every node into the node
+// named by group. Two merged nodes are adjacent if any of their members were,
+// edges within a merged node are dropped.
+func (g *Graph) quotient(group map[nodeID]nodeName) *Graph {
+	q := New()
+	for _, id := range g.nodes.ids() {
+		q.AddNode(string(group[id]))
+	}
+	for _, e := range g.nodes.edges() {
+		if a, b := group[e[0]], group[e[1]]; a != b {
+			q.AddEdge(string(a), string(b))
+		}
+	}
+	return q
+}
+
+// CoarsenLevels returns a hierarchy of progressively coarser versions of the
+// graph, one per level. Each level is built from the previous one by finding
+// a maximal matching and contracting every matched pair of nodes into a
+// super-node named after both, joined by "+". Nodes are matched greedily in
+// ascending order with their first unmatched neighbor, so every level has
+// roughly half the nodes of the previous one. It stops early once a level has
+// no edges left to contract. It returns an error wrapping ErrNameCollision if
+// the name of a super-node is already taken by another node on its level.
+func (g *Graph) CoarsenLevels(levels int) ([]*Graph, error) {
+	var hierarchy []*Graph
+	current := g
+	for level := 0; level < levels; level++ {
+		names := current.symbolTable.names()
+		group := make(map[nodeID]nodeName, len(current.nodes))
+		supers := make(map[nodeName]bool)
+		matched := false
+		for _, id := range current.nodes.ids() {
+			if _, ok := group[id]; ok {
+				continue
+			}
+			group[id] = names[id]
+
+			adj := make([]nodeID, 0, len(current.nodes[id].adj))
+			for other := range current.nodes[id].adj {
+				adj = append(adj, other)
+			}
+			sortIDs(adj)
+			for _, other := range adj {
+				if _, ok := group[other]; ok || other == id {
+					continue
+				}
+				super := names[id] + "+" + names[other]
+				group[id], group[other] = super, super
+				supers[super] = true
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			break
+		}
+
+		// Every super-node must be made of exactly its pair, every other name
+		// of its own node.
+		count := make(map[nodeName]int, len(group))
+		for _, name := range group {
+			count[name]++
+		}
+		for _, id := range current.nodes.ids() {
+			name := group[id]
+			if supers[name] && count[name] != 2 || !supers[name] && count[name] != 1 {
+				return nil, fmt.Errorf("level %d: %q: %w", level, name, ErrNameCollision)
+			}
+		}
+
+		current = current.quotient(group)
+		hierarchy = append(hierarchy, current)
+	}
+	return hierarchy, nil
+}
+
+// maxCore returns the nodes of the k-core with the largest k, the largest
+// subgraph in which every node has at least k neighbors, together with k. It
+// repeatedly peels off a node of minimum degree, the core number is the
+// largest minimum degree seen while peeling. Self-loops are ignored.
+func (nodes nodes) maxCore() (map[nodeID]bool, int) {
+	degree := make(map[nodeID]int, len(nodes))
+	for id := range nodes {
+		degree[id] = nodes.degree(id)
+	}
+
+	remaining := make(map[nodeID]bool, len(nodes))
+	for id := range nodes {
+		remaining[id] = true
+	}
+	best, bestK := make(map[nodeID]bool), 0
+	for k := 0; len(remaining) > 0; {
+		// Find the remaining node of minimum degree, lowest id on ties.
+		var min nodeID
+		minDegree := -1
+		for id := range remaining {
+			if minDegree < 0 || degree[id] < minDegree || (degree[id] == minDegree && id < min) {
+				min, minDegree = id, degree[id]
+			}
+		}
+		if minDegree > k {
+			k = minDegree
+		}
+		if k > bestK || len(best) == 0 {
+			// Everything that remains is part of the k-core.
+			best, bestK = make(map[nodeID]bool, len(remaining)), k
+			for id := range remaining {
+				best[id] = true
+			}
+		}
+
+		delete(remaining, min)
+		for adj := range nodes[min].adj {
+			if remaining[adj] && adj != min {
+				degree[adj]--
+			}
+		}
+	}
+	return best, bestK
+}
+
+// ContractDensestAndDiameter contracts the densest region of the graph into a
+// single super-node and returns the diameter of the result along with the
+// name of the super-node. The densest region is the largest connected part of
+// the k-core with the largest k. The super-node is named after its members in
+// ascending order, joined by "+". The empty graph returns 0 and "". It returns
+// an error wrapping ErrNameCollision if a node outside the region already has
+// the name of the super-node.
+func (g *Graph) ContractDensestAndDiameter() (int, string, error) {
+	core, _ := g.nodes.maxCore()
+	if len(core) == 0 {
+		return 0, "", nil
+	}
+
+	// Pick the largest connected piece of the core.
+	sub := g.nodes.clone()
+	for id := range g.nodes {
+		if !core[id] {
+			sub.removeNode(id)
+		}
+	}
+	label, sizes := sub.components()
+	largest := 0
+	for c, size := range sizes {
+		if size > sizes[largest] {
+			largest = c
+		}
+	}
+
+	names := g.symbolTable.names()
+	var members []string
+	for id, c := range label {
+		if c == largest {
+			members = append(members, string(names[id]))
+		}
+	}
+	sort.Strings(members)
+	super := nodeName(strings.Join(members, "+"))
+	if id, err := g.find(string(super)); err == nil {
+		if c, ok := label[id]; !ok || c != largest {
+			return 0, "", fmt.Errorf("%q: %w", super, ErrNameCollision)
+		}
+	}
+
+	group := make(map[nodeID]nodeName, len(g.nodes))
+	for id := range g.nodes {
+		if c, ok := label[id]; ok && c == largest {
+			group[id] = super
+		} else {
+			group[id] = names[id]
+		}
+	}
+	return g.quotient(group).Diameter(), string(super), nil
+}
@@ -0,0 +1,239 @@
+package diameter
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCartesianProduct(t *testing.T) {
+
+	tests := []struct {
+		name        string
+		a, b        edgeList
+		isolated    []string
+		expNodes    int
+		expEdges    int
+		expDiameter int
+	}{
+		{
+			name: "empty",
+			b:    edgeList{{"x", "y"}},
+		},
+		{
+			name:        "1 edge squared",
+			a:           edgeList{{"a", "b"}},
+			b:           edgeList{{"x", "y"}},
+			expNodes:    4,
+			expEdges:    4,
+			expDiameter: 2,
+		},
+		{
+			name:        "grid",
+			a:           edgeList{{"a", "b"}, {"b", "c"}},
+			b:           edgeList{{"x", "y"}, {"y", "z"}, {"z", "w"}},
+			expNodes:    12,
+			expEdges:    17,
+			expDiameter: 5,
+		},
+		{
+			name:        "prism",
+			a:           edgeList{{"a", "b"}, {"b", "c"}, {"a", "c"}},
+			b:           edgeList{{"x", "y"}},
+			expNodes:    6,
+			expEdges:    9,
+			expDiameter: 2,
+		},
+		{
+			name:     "single nodes",
+			isolated: []string{"q"},
+			expNodes: 1,
+		},
+		{
+			name:        "edge and isolated node squared",
+			a:           edgeList{{"a", "b"}},
+			b:           edgeList{{"a", "b"}},
+			isolated:    []string{"q"},
+			expNodes:    9,
+			expEdges:    6,
+			expDiameter: 2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a, b := New(), New()
+			test.a.build(a)
+			test.b.build(b)
+			for _, name := range test.isolated {
+				a.AddNode(name)
+				b.AddNode(name)
+			}
+			p := a.CartesianProduct(b)
+			if len(p.nodes) != test.expNodes {
+				t.Errorf("Number of nodes not as expected. Have %d, expected %d", len(p.nodes), test.expNodes)
+			}
+			if e := len(p.nodes.edges()); e != test.expEdges {
+				t.Errorf("Number of edges not as expected. Have %d, expected %d", e, test.expEdges)
+			}
+			if d := p.Diameter(); d != test.expDiameter {
+				t.Errorf("Diameter not as expected. Have %d, expected %d", d, test.expDiameter)
+			}
+		})
+	}
+
+	t.Run("names", func(t *testing.T) {
+		a, b := New(), New()
+		edgeList{{"a", "b"}}.build(a)
+		edgeList{{"x", "y"}}.build(b)
+		p := a.CartesianProduct(b)
+		for _, e := range []edge{{"(a,x)", "(a,y)"}, {"(a,x)", "(b,x)"}, {"(b,y)", "(a,y)"}, {"(b,y)", "(b,x)"}} {
+			if !p.nodes.adjacent(p.symbolTable.byName[e.a], p.symbolTable.byName[e.b]) {
+				t.Errorf("Expected %s and %s to be adjacent", e.a, e.b)
+			}
+		}
+	})
+}
+
+func TestCoarsenLevels(t *testing.T) {
+	g := New()
+	edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}, {"d", "e"}, {"e", "f"}, {"f", "g"}, {"g", "h"}}.build(g)
+
+	levels, err := g.CoarsenLevels(5)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	expNodes := []int{4, 2, 1}
+	if len(levels) != len(expNodes) {
+		t.Fatalf("Number of levels not as expected. Have %d, expected %d", len(levels), len(expNodes))
+	}
+	for i, level := range levels {
+		if len(level.nodes) != expNodes[i] {
+			t.Errorf("Number of nodes on level %d not as expected. Have %d, expected %d", i, len(level.nodes), expNodes[i])
+		}
+		if d := level.Diameter(); d != expNodes[i]-1 {
+			t.Errorf("Level %d is not a path, diameter is %d", i, d)
+		}
+	}
+
+	if _, ok := levels[0].symbolTable.lookup("a+b"); !ok {
+		t.Errorf("Expected super-node a+b on the first level")
+	}
+	if _, ok := levels[2].symbolTable.lookup("a+b+c+d+e+f+g+h"); !ok {
+		t.Errorf("Expected a single super-node on the last level")
+	}
+	if len(g.nodes) != 8 {
+		t.Errorf("Graph was modified")
+	}
+}
+
+func TestCoarsenLevelsNameCollision(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		edgeList edgeList
+		isolated []string
+	}{
+		{
+			name:     "super-node name taken",
+			edgeList: edgeList{{"a", "b"}},
+			isolated: []string{"a+b"},
+		},
+		{
+			name:     "two super-nodes with the same name",
+			edgeList: edgeList{{"a+b", "c"}, {"a", "b+c"}},
+		},
+		{
+			// The collision only shows up on the second level, when a+b
+			// and c+d merge into a+b+c+d.
+			name:     "later level",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}},
+			isolated: []string{"a+b+c+d"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := New()
+			test.edgeList.build(g)
+			for _, name := range test.isolated {
+				g.AddNode(name)
+			}
+			if _, err := g.CoarsenLevels(3); !errors.Is(err, ErrNameCollision) {
+				t.Errorf("Expected error to wrap ErrNameCollision, have %v", err)
+			}
+		})
+	}
+}
+
+func TestContractDensestAndDiameter(t *testing.T) {
+
+	tests := []struct {
+		name        string
+		edgeList    edgeList
+		isolated    []string
+		expDiameter int
+		expName     string
+		expErr      error
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:        "Triangle",
+			edgeList:    edgeList{{"a", "b"}, {"b", "c"}, {"a", "c"}},
+			expDiameter: 0,
+			expName:     "a+b+c",
+		},
+		{
+			// A K4 lobe and a triangle lobe joined by the path d-x-e. The
+			// K4 is the 3-core, contracting it leaves the path s-x-e plus
+			// the triangle e,f,g, diameter 3 instead of 4.
+			name: "barbell",
+			edgeList: edgeList{
+				{"a", "b"}, {"a", "c"}, {"a", "d"}, {"b", "c"}, {"b", "d"}, {"c", "d"},
+				{"d", "x"}, {"x", "e"},
+				{"e", "f"}, {"f", "g"}, {"e", "g"},
+			},
+			expDiameter: 3,
+			expName:     "a+b+c+d",
+		},
+		{
+			// Every node is in the 1-core, the whole path contracts.
+			name:     "4 in line",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}},
+			expName:  "a+b+c+d",
+		},
+		{
+			name:     "super-node name taken",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"a", "c"}},
+			isolated: []string{"a+b+c"},
+			expErr:   ErrNameCollision,
+		},
+		{
+			// The only member already has the super-node's name.
+			name:     "single member",
+			edgeList: edgeList{{"a+b", "a+b"}},
+			expName:  "a+b",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := New()
+			test.edgeList.build(g)
+			for _, name := range test.isolated {
+				g.AddNode(name)
+			}
+			d, name, err := g.ContractDensestAndDiameter()
+			if !errors.Is(err, test.expErr) {
+				t.Errorf("Error not as expected. Have %v, expected %v", err, test.expErr)
+			}
+			if d != test.expDiameter {
+				t.Errorf("Diameter not as expected. Have %d, expected %d", d, test.expDiameter)
+			}
+			if name != test.expName {
+				t.Errorf("Super-node not as expected. Have %s, expected %s", name, test.expName)
+			}
+		})
+	}
+}
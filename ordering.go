@@ -0,0 +1,79 @@
+package diameter
+
+import (
+	"fmt"
+	"sort"
+)
+
+// CuthillMcKeeOrder returns the nodes in Cuthill–McKee order, which tends to
+// keep adjacent nodes close together and so reduces the bandwidth of the
+// adjacency matrix. Every component is traversed breadth first, starting from
+// its node of lowest degree and visiting the neighbors of each node in order
+// of increasing degree.
+func (g *Graph) CuthillMcKeeOrder() []string {
+	ids := g.nodes.ids()
+	byDegree := func(list []nodeID) {
+		sort.SliceStable(list, func(i, j int) bool { return g.nodes.degree(list[i]) < g.nodes.degree(list[j]) })
+	}
+	byDegree(ids)
+
+	order := make([]nodeID, 0, len(ids))
+	visited := make(map[nodeID]bool, len(ids))
+	for _, start := range ids {
+		if visited[start] {
+			continue
+		}
+		visited[start] = true
+		queue := []nodeID{start}
+		for len(queue) > 0 {
+			id := queue[0]
+			queue = queue[1:]
+			order = append(order, id)
+
+			var next []nodeID
+			for adj := range g.nodes[id].adj {
+				if !visited[adj] {
+					visited[adj] = true
+					next = append(next, adj)
+				}
+			}
+			sortIDs(next)
+			byDegree(next)
+			queue = append(queue, next...)
+		}
+	}
+	return g.symbolTable.toStrings(order)
+}
+
+// Bandwidth returns the bandwidth of the graph under the given ordering of
+// its nodes, the largest distance between the positions of two adjacent
+// nodes. It returns an error if the ordering contains unknown or duplicate
+// nodes, or misses any node of the graph.
+func (g *Graph) Bandwidth(order []string) (int, error) {
+	pos := make(map[nodeID]int, len(order))
+	for i, name := range order {
+		id, err := g.find(name)
+		if err != nil {
+			return 0, fmt.Errorf("diameter: unknown node %q in ordering", name)
+		}
+		if _, dup := pos[id]; dup {
+			return 0, fmt.Errorf("diameter: node %q appears twice in ordering", name)
+		}
+		pos[id] = i
+	}
+	if len(pos) != len(g.nodes) {
+		return 0, fmt.Errorf("diameter: ordering covers %d of %d nodes", len(pos), len(g.nodes))
+	}
+
+	var bandwidth int
+	for _, e := range g.nodes.edges() {
+		span := pos[e[0]] - pos[e[1]]
+		if span < 0 {
+			span = -span
+		}
+		if span > bandwidth {
+			bandwidth = span
+		}
+	}
+	return bandwidth, nil
+}
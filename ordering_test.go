@@ -0,0 +1,81 @@
+package diameter
+
+import "testing"
+
+func TestCuthillMcKeeOrder(t *testing.T) {
+	g := New()
+	edgeList{{"c", "d"}, {"a", "b"}, {"d", "e"}, {"b", "c"}, {"x", "y"}}.build(g)
+
+	order := g.CuthillMcKeeOrder()
+	exp := []string{"a", "b", "c", "d", "e", "x", "y"}
+	if len(order) != len(exp) {
+		t.Fatalf("Order not as expected. Have %v, expected %v", order, exp)
+	}
+	for i := range exp {
+		if order[i] != exp[i] {
+			t.Fatalf("Order not as expected. Have %v, expected %v", order, exp)
+		}
+	}
+}
+
+func TestBandwidth(t *testing.T) {
+	el := edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}, {"d", "e"}}
+
+	tests := []struct {
+		name   string
+		order  []string
+		exp    int
+		expErr bool
+	}{
+		{
+			name:  "path order",
+			order: []string{"a", "b", "c", "d", "e"},
+			exp:   1,
+		},
+		{
+			name:  "interleaved",
+			order: []string{"a", "c", "e", "b", "d"},
+			exp:   3,
+		},
+		{
+			name:   "missing",
+			order:  []string{"a", "b", "c", "d"},
+			expErr: true,
+		},
+		{
+			name:   "duplicate",
+			order:  []string{"a", "b", "c", "d", "e", "a"},
+			expErr: true,
+		},
+		{
+			name:   "unknown",
+			order:  []string{"a", "b", "c", "d", "e", "x"},
+			expErr: true,
+		},
+	}
+
+	g := New()
+	el.build(g)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b, err := g.Bandwidth(test.order)
+			if (err != nil) != test.expErr {
+				t.Fatalf("Unexpected error %v", err)
+			}
+			if b != test.exp {
+				t.Errorf("Bandwidth not as expected. Have %d, expected %d", b, test.exp)
+			}
+		})
+	}
+
+	t.Run("Cuthill-McKee reduces bandwidth", func(t *testing.T) {
+		naive, _ := g.Bandwidth([]string{"a", "c", "e", "b", "d"})
+		cm, err := g.Bandwidth(g.CuthillMcKeeOrder())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cm != 1 || cm >= naive {
+			t.Errorf("Expected Cuthill-McKee bandwidth 1 below %d, have %d", naive, cm)
+		}
+	})
+}
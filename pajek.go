@@ -0,0 +1,117 @@
+package diameter
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WritePajek writes the graph in the Pajek .net format: a *Vertices section
+// numbering the nodes from 1 with their quoted names, followed by an *Edges
+// section with one line per edge holding the numbers of its two nodes. Nodes
+// are numbered in the order they were added to the graph, edges are sorted by
+// their node numbers. Pajek has no way to escape a quote, so it returns an
+// error without writing anything if a node name contains one.
+func (g *Graph) WritePajek(w io.Writer) error {
+	ids, index := g.nodes.index()
+	names := g.symbolTable.names()
+	for _, id := range ids {
+		if strings.Contains(string(names[id]), `"`) {
+			return fmt.Errorf("diameter: node name %q contains a quote", names[id])
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "*Vertices %d\n", len(ids))
+	for i, id := range ids {
+		fmt.Fprintf(bw, "%d \"%s\"\n", i+1, names[id])
+	}
+	fmt.Fprintln(bw, "*Edges")
+	for i, id := range ids {
+		for _, adj := range g.nodes.neighbors(id) {
+			if adj >= id {
+				fmt.Fprintf(bw, "%d %d\n", i+1, index[adj]+1)
+			}
+		}
+	}
+	return bw.Flush()
+}
+
+// LoadPajek reads a graph in the Pajek .net format. It skips the *Network
+// header and understands a *Vertices section of numbered, optionally quoted,
+// node names and *Edges and *Arcs sections of node number pairs. The graph is
+// undirected, so arcs are read as edges. Anything after the node name or pair
+// on a line, like coordinates or weights, is ignored, as are blank lines and
+// comments starting with %. Nodes listed without edges are added to the
+// graph. It returns an error on unknown or unsupported sections like
+// *Edgeslist, malformed lines and edges to nodes that weren't listed.
+func LoadPajek(r io.Reader) (*Graph, error) {
+	g := New()
+	names := make(map[string]nodeName)
+	section := ""
+
+	s := bufio.NewScanner(r)
+	for line := 1; s.Scan(); line++ {
+		text := strings.TrimSpace(s.Text())
+		if text == "" || strings.HasPrefix(text, "%") {
+			continue
+		}
+
+		if strings.HasPrefix(text, "*") {
+			fields := strings.Fields(text)
+			switch header := strings.ToLower(fields[0]); header {
+			case "*network":
+				// The name of the network is of no use to the graph.
+			case "*vertices", "*edges", "*arcs":
+				section = header
+			case "*edgeslist", "*arcslist":
+				return nil, fmt.Errorf("diameter: line %d: unsupported section %s", line, fields[0])
+			default:
+				return nil, fmt.Errorf("diameter: line %d: unknown section %s", line, fields[0])
+			}
+			continue
+		}
+
+		switch section {
+		case "*vertices":
+			fields := strings.Fields(text)
+			num := fields[0]
+			name := strings.TrimSpace(strings.TrimPrefix(text, num))
+			if strings.HasPrefix(name, `"`) {
+				end := strings.Index(name[1:], `"`)
+				if end < 0 {
+					return nil, fmt.Errorf("diameter: line %d: unterminated node name", line)
+				}
+				name = name[1 : end+1]
+			} else if f := strings.Fields(name); len(f) > 0 {
+				name = f[0]
+			} else {
+				// A vertex without a name is named by its number.
+				name = num
+			}
+			names[num] = nodeName(name)
+			g.AddNode(name)
+		case "*edges", "*arcs":
+			fields := strings.Fields(text)
+			if len(fields) < 2 {
+				return nil, fmt.Errorf("diameter: line %d: expected two node numbers, have %q", line, text)
+			}
+			a, ok := names[fields[0]]
+			if !ok {
+				return nil, fmt.Errorf("diameter: line %d: unknown node %s", line, fields[0])
+			}
+			b, ok := names[fields[1]]
+			if !ok {
+				return nil, fmt.Errorf("diameter: line %d: unknown node %s", line, fields[1])
+			}
+			g.AddEdge(string(a), string(b))
+		default:
+			return nil, fmt.Errorf("diameter: line %d: data outside of a section", line)
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
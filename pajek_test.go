@@ -0,0 +1,218 @@
+package diameter
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestWritePajek(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		edgeList edgeList
+		exp      string
+	}{
+		{
+			name: "empty",
+			exp:  "*Vertices 0\n*Edges\n",
+		},
+		{
+			name:     "Triangle",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"a", "c"}},
+			exp: `*Vertices 3
+1 "a"
+2 "b"
+3 "c"
+*Edges
+1 2
+1 3
+2 3
+`,
+		},
+		{
+			name:     "spaces and self-loop",
+			edgeList: edgeList{{"new york", "boston"}, {"boston", "boston"}},
+			exp: `*Vertices 2
+1 "new york"
+2 "boston"
+*Edges
+1 2
+2 2
+`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := New()
+			test.edgeList.build(g)
+			var buf bytes.Buffer
+			if err := g.WritePajek(&buf); err != nil {
+				t.Fatal(err)
+			}
+			if buf.String() != test.exp {
+				t.Errorf("Output not as expected. Have\n%s\nexpected\n%s", buf.String(), test.exp)
+			}
+		})
+	}
+}
+
+// failingWriter fails every write.
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("write failed") }
+
+func TestWritePajekError(t *testing.T) {
+	g := New()
+	edgeList{{"a", "b"}}.build(g)
+	if err := g.WritePajek(failingWriter{}); err == nil {
+		t.Errorf("Expected the write error to be returned")
+	}
+}
+
+func TestWritePajekQuote(t *testing.T) {
+	g := New()
+	edgeList{{"a", "say \"hi\""}}.build(g)
+	var b bytes.Buffer
+	if err := g.WritePajek(&b); err == nil {
+		t.Errorf("Expected an error for a name with a quote")
+	}
+	if b.Len() != 0 {
+		t.Errorf("Expected nothing to be written, have %q", b.String())
+	}
+}
+
+func TestLoadPajek(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		input    string
+		expNodes int
+		expEdges edgeList
+		expErr   bool
+	}{
+		{
+			name: "Triangle",
+			input: `*Vertices 3
+1 "a"
+2 "b"
+3 "c"
+*Edges
+1 2
+1 3
+2 3
+`,
+			expNodes: 3,
+			expEdges: edgeList{{"a", "b"}, {"a", "c"}, {"b", "c"}},
+		},
+		{
+			name: "arcs, comments and extras",
+			input: `% a comment
+*vertices 4
+1 "new york" 0.1 0.2
+2 boston
+3 "isolated"
+4
+
+*Arcs
+1 2 1.5
+2 4
+`,
+			expNodes: 4,
+			expEdges: edgeList{{"new york", "boston"}, {"boston", "4"}},
+		},
+		{
+			name: "network header",
+			input: `*Network routes
+*Vertices 2
+1 "a"
+2 "b"
+*Edges
+1 2
+`,
+			expNodes: 2,
+			expEdges: edgeList{{"a", "b"}},
+		},
+		{
+			name:   "edges list",
+			input:  "*Vertices 3\n1 \"a\"\n2 \"b\"\n3 \"c\"\n*Edgeslist\n1 2 3\n",
+			expErr: true,
+		},
+		{
+			name:   "unknown section",
+			input:  "*Vertices 1\n1 \"a\"\n*Matrix\n",
+			expErr: true,
+		},
+		{
+			name:   "unknown node",
+			input:  "*Vertices 1\n1 \"a\"\n*Edges\n1 2\n",
+			expErr: true,
+		},
+		{
+			name:   "short edge",
+			input:  "*Vertices 1\n1 \"a\"\n*Edges\n1\n",
+			expErr: true,
+		},
+		{
+			name:   "no section",
+			input:  "1 2\n",
+			expErr: true,
+		},
+		{
+			name:   "unterminated name",
+			input:  "*Vertices 1\n1 \"a\n",
+			expErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g, err := LoadPajek(strings.NewReader(test.input))
+			if (err != nil) != test.expErr {
+				t.Fatalf("Unexpected error %v", err)
+			}
+			if err != nil {
+				return
+			}
+			if len(g.nodes) != test.expNodes {
+				t.Errorf("Number of nodes not as expected. Have %d, expected %d", len(g.nodes), test.expNodes)
+			}
+			if e := len(g.nodes.edges()); e != len(test.expEdges) {
+				t.Errorf("Number of edges not as expected. Have %d, expected %d", e, len(test.expEdges))
+			}
+			for _, e := range test.expEdges {
+				if !g.nodes.adjacent(g.symbolTable.byName[e.a], g.symbolTable.byName[e.b]) {
+					t.Errorf("Edge %s-%s missing", e.a, e.b)
+				}
+			}
+		})
+	}
+}
+
+func TestPajekRoundTrip(t *testing.T) {
+	g := New()
+	edgeList{{"a", "b"}, {"b", "c"}, {"c", "a"}, {"c", "d"}, {"d", "e"}, {"e", "c"}, {"x y", "x y"}}.build(g)
+	// Pajek has no escapes, a backslash is an ordinary character.
+	edgeList{{`x\`, `\\`}, {`\\`, "a"}}.build(g)
+
+	var first bytes.Buffer
+	if err := g.WritePajek(&first); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadPajek(bytes.NewReader(first.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var second bytes.Buffer
+	if err := loaded.WritePajek(&second); err != nil {
+		t.Fatal(err)
+	}
+	if first.String() != second.String() {
+		t.Errorf("Round trip not as expected. Have\n%s\nexpected\n%s", second.String(), first.String())
+	}
+	if loaded.Diameter() != g.Diameter() {
+		t.Errorf("Diameter not as expected. Have %d, expected %d", loaded.Diameter(), g.Diameter())
+	}
+}
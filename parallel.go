@@ -0,0 +1,75 @@
+package diameter
+
+import (
+	"runtime"
+	"sync"
+)
+
+// ParallelForEachNode calls f with the name of every node in the graph,
+// spreading the calls over runtime.NumCPU() goroutines. The graph is not
+// locked, so f may read from the graph but must not modify it. It returns
+// once all calls have completed.
+func (g *Graph) ParallelForEachNode(f func(name string)) {
+	names := g.symbolTable.names()
+	work := make(chan nodeID)
+
+	var wg sync.WaitGroup
+	for i := 0; i < runtime.NumCPU(); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range work {
+				f(string(names[id]))
+			}
+		}()
+	}
+
+	for id := range g.nodes {
+		work <- id
+	}
+	close(work)
+	wg.Wait()
+}
+
+// DiameterParallel computes the diameter like Diameter does, running the BFS
+// from the different start nodes concurrently on the given number of
+// goroutines. If workers is not positive runtime.NumCPU() goroutines are
+// used, and never more than there are nodes. The graph must not be modified
+// until it returns.
+func (g *Graph) DiameterParallel(workers int) int {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	// Every goroutine allocates its own BFS buffers, don't start idle ones.
+	if workers > len(g.nodes) {
+		workers = len(g.nodes)
+	}
+	work := make(chan nodeID)
+	results := make(chan int, workers)
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			var diameter int
+			s := g.nodes.newBFSScratch()
+			for id := range work {
+				if df := g.nodes.longestShortestPath(s, id); df > diameter {
+					diameter = df
+				}
+			}
+			results <- diameter
+		}()
+	}
+
+	for id := range g.nodes {
+		work <- id
+	}
+	close(work)
+
+	var diameter int
+	for i := 0; i < workers; i++ {
+		if df := <-results; df > diameter {
+			diameter = df
+		}
+	}
+	return diameter
+}
@@ -0,0 +1,58 @@
+package diameter
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestParallelForEachNode(t *testing.T) {
+	g := New()
+	edgeList{{"a", "b"}, {"b", "c"}, {"c", "a"}, {"c", "d"}, {"d", "e"}, {"e", "c"}}.build(g)
+
+	var calls int64
+	var mu sync.Mutex
+	seen := make(map[string]int)
+	g.ParallelForEachNode(func(name string) {
+		atomic.AddInt64(&calls, 1)
+		// Reading the graph concurrently must be safe.
+		g.ReachableCounts()
+		mu.Lock()
+		seen[name]++
+		mu.Unlock()
+	})
+
+	if int(calls) != len(g.nodes) {
+		t.Errorf("Number of calls not as expected. Have %d, expected %d", calls, len(g.nodes))
+	}
+	for name := range g.symbolTable.byName {
+		if seen[string(name)] != 1 {
+			t.Errorf("Node %s visited %d times, expected once", name, seen[string(name)])
+		}
+	}
+}
+
+func TestDiameterParallel(t *testing.T) {
+	tests := []struct {
+		name     string
+		edgeList edgeList
+	}{
+		{name: "empty"},
+		{name: "triangle", edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"c", "a"}}},
+		{name: "two triangles", edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"c", "a"}, {"c", "d"}, {"d", "e"}, {"e", "c"}, {"e", "f"}}},
+		{name: "disconnected", edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}, {"x", "y"}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := New()
+			test.edgeList.build(g)
+			exp := g.Diameter()
+			for _, workers := range []int{-1, 0, 1, 3, 16} {
+				if d := g.DiameterParallel(workers); d != exp {
+					t.Errorf("Diameter with %d workers not as expected. Have %d, expected %d", workers, d, exp)
+				}
+			}
+		})
+	}
+}
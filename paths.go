@@ -0,0 +1,288 @@
+package diameter
+
+import (
+	"fmt"
+	"sort"
+)
+
+// bfsTree runs a BFS from start and returns the depth and the BFS tree parent
+// of every node reachable from it. start is its own parent. Neighbors are
+// visited in ascending id order, which makes the path to every node in the
+// tree the lexicographically smallest shortest path.
+func (nodes nodes) bfsTree(start nodeID) (map[nodeID]int, map[nodeID]nodeID) {
+	return nodes.bfsTreeAvoiding(start, nil, nil)
+}
+
+// bfsTreeAvoiding runs a BFS from start like bfsTree, without using any of the
+// edges in avoid or entering any of the nodes in skip.
+func (nodes nodes) bfsTreeAvoiding(start nodeID, avoid map[nodePair]bool, skip map[nodeID]bool) (map[nodeID]int, map[nodeID]nodeID) {
+	depth := map[nodeID]int{start: 0}
+	parent := map[nodeID]nodeID{start: start}
+	queue := []nodeID{start}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, adj := range nodes.neighbors(id) {
+			if _, seen := depth[adj]; !seen && !skip[adj] && !avoid[newNodePair(id, adj)] {
+				depth[adj] = depth[id] + 1
+				parent[adj] = id
+				queue = append(queue, adj)
+			}
+		}
+	}
+	return depth, parent
+}
+
+// pathTo follows the BFS tree parents from id back to the root and returns
+// the path from the root to id.
+func pathTo(parent map[nodeID]nodeID, id nodeID) []nodeID {
+	path := []nodeID{id}
+	for parent[id] != id {
+		id = parent[id]
+		path = append(path, id)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// ShortestCycleThrough returns a shortest cycle containing the named node, as
+// the list of nodes along the cycle starting with the node itself. It returns
+// false if the node is unknown or not on any cycle. Self-loops are not
+// considered cycles.
+//
+// A BFS from the node assigns every other node to the branch, the neighbor of
+// the start node, it was reached through. An edge between two nodes of
+// different branches closes a cycle through the start node, the shortest one
+// is found from the edge with the smallest sum of depths.
+func (g *Graph) ShortestCycleThrough(name string) ([]string, bool) {
+	start, err := g.find(name)
+	if err != nil {
+		return nil, false
+	}
+
+	depth, parent := g.nodes.bfsTree(start)
+	branch := make(map[nodeID]nodeID, len(depth))
+	var branchOf func(id nodeID) nodeID
+	branchOf = func(id nodeID) nodeID {
+		if b, ok := branch[id]; ok {
+			return b
+		}
+		b := id
+		if parent[id] != start {
+			b = branchOf(parent[id])
+		}
+		branch[id] = b
+		return b
+	}
+
+	var closing nodePair
+	length := -1
+	for _, e := range g.nodes.edges() {
+		u, v := e[0], e[1]
+		if _, reached := depth[u]; !reached || u == start || v == start {
+			continue
+		}
+		if branchOf(u) == branchOf(v) {
+			continue
+		}
+		if l := depth[u] + depth[v] + 1; length < 0 || l < length {
+			closing, length = e, l
+		}
+	}
+	if length < 0 {
+		return nil, false
+	}
+
+	cycle := pathTo(parent, closing[0])
+	back := pathTo(parent, closing[1])
+	for i := len(back) - 1; i > 0; i-- {
+		cycle = append(cycle, back[i])
+	}
+	return g.symbolTable.toStrings(cycle), true
+}
+
+// shortestPath returns a shortest path from node from to node to, including
+// both ends. It returns false if to can't be reached from from.
+func (nodes nodes) shortestPath(from, to nodeID) ([]nodeID, bool) {
+	_, parent := nodes.bfsTree(from)
+	if _, ok := parent[to]; !ok {
+		return nil, false
+	}
+	return pathTo(parent, to), true
+}
+
+// ShortestPath returns a shortest path from node a to node b, including both
+// ends, and its length in edges. It returns an error wrapping ErrNodeNotFound
+// if either node is unknown, or ErrNoPath if b can't be reached from a.
+func (g *Graph) ShortestPath(a, b string) ([]string, int, error) {
+	from, err := g.find(a)
+	if err != nil {
+		return nil, 0, err
+	}
+	to, err := g.find(b)
+	if err != nil {
+		return nil, 0, err
+	}
+	path, ok := g.nodes.shortestPath(from, to)
+	if !ok {
+		return nil, 0, fmt.Errorf("%q to %q: %w", a, b, ErrNoPath)
+	}
+	return g.symbolTable.toStrings(path), len(path) - 1, nil
+}
+
+// PathThroughWaypoints returns a shortest walk that visits the given
+// waypoints in order, from the first to the last, and its length. The walk is
+// made of a shortest path between every two consecutive waypoints, so it may
+// visit nodes more than once. It returns false if there are no waypoints, a
+// waypoint is unknown, or a waypoint can't be reached from the previous one.
+func (g *Graph) PathThroughWaypoints(waypoints []string) ([]string, int, bool) {
+	if len(waypoints) == 0 {
+		return nil, 0, false
+	}
+	ids := make([]nodeID, len(waypoints))
+	for i, name := range waypoints {
+		id, err := g.find(name)
+		if err != nil {
+			return nil, 0, false
+		}
+		ids[i] = id
+	}
+
+	walk := []nodeID{ids[0]}
+	for i := 1; i < len(ids); i++ {
+		leg, ok := g.nodes.shortestPath(ids[i-1], ids[i])
+		if !ok {
+			return nil, 0, false
+		}
+		walk = append(walk, leg[1:]...)
+	}
+	return g.symbolTable.toStrings(walk), len(walk) - 1, true
+}
+
+// ShortestPathAvoiding returns a shortest path from node from to node to that
+// doesn't use any of the edges in avoid, given as pairs of node names in
+// either order. The edges are skipped during the search, the graph is not
+// modified. It returns false if a node is unknown or no such path exists.
+func (g *Graph) ShortestPathAvoiding(from, to string, avoid [][2]string) ([]string, bool) {
+	a, err := g.find(from)
+	if err != nil {
+		return nil, false
+	}
+	b, err := g.find(to)
+	if err != nil {
+		return nil, false
+	}
+
+	skip := make(map[nodePair]bool, len(avoid))
+	for _, e := range avoid {
+		u, uerr := g.find(e[0])
+		v, verr := g.find(e[1])
+		if uerr == nil && verr == nil {
+			skip[newNodePair(u, v)] = true
+		}
+	}
+
+	_, parent := g.nodes.bfsTreeAvoiding(a, skip, nil)
+	if _, ok := parent[b]; !ok {
+		return nil, false
+	}
+	return g.symbolTable.toStrings(pathTo(parent, b)), true
+}
+
+// KShortestPaths returns up to k shortest paths from node from to node to that
+// don't visit any node twice, in order of increasing length. They are found
+// with Yen's algorithm: every further path deviates from one of the paths
+// found so far at some spur node, with the shortest spur path from there that
+// avoids the edges the known paths take at that point. Paths of equal length
+// are ordered by the order their nodes were added to the graph. It returns
+// fewer than k paths if there aren't that many, and false if k is less than
+// 1, a node is unknown or there is no path at all.
+func (g *Graph) KShortestPaths(from, to string, k int) ([][]string, bool) {
+	a, err := g.find(from)
+	if err != nil || k < 1 {
+		return nil, false
+	}
+	b, err := g.find(to)
+	if err != nil {
+		return nil, false
+	}
+
+	first, ok := g.nodes.shortestPath(a, b)
+	if !ok {
+		return nil, false
+	}
+	found := [][]nodeID{first}
+	var candidates [][]nodeID
+	known := map[string]bool{fmt.Sprint(first): true}
+
+	for len(found) < k {
+		prev := found[len(found)-1]
+		for i := 0; i < len(prev)-1; i++ {
+			root := prev[:i+1]
+
+			// Don't repeat the known paths sharing this root, and don't
+			// return to the root.
+			avoid := make(map[nodePair]bool)
+			for _, p := range found {
+				if len(p) > i+1 && equalIDs(p[:i+1], root) {
+					avoid[newNodePair(p[i], p[i+1])] = true
+				}
+			}
+			skip := make(map[nodeID]bool, i)
+			for _, id := range root[:i] {
+				skip[id] = true
+			}
+
+			_, parent := g.nodes.bfsTreeAvoiding(prev[i], avoid, skip)
+			if _, ok := parent[b]; !ok {
+				continue
+			}
+			path := append(append([]nodeID{}, root[:i]...), pathTo(parent, b)...)
+			if key := fmt.Sprint(path); !known[key] {
+				known[key] = true
+				candidates = append(candidates, path)
+			}
+		}
+		if len(candidates) == 0 {
+			break
+		}
+
+		sort.SliceStable(candidates, func(i, j int) bool { return lessPath(candidates[i], candidates[j]) })
+		found = append(found, candidates[0])
+		candidates = candidates[1:]
+	}
+
+	paths := make([][]string, len(found))
+	for i, p := range found {
+		paths[i] = g.symbolTable.toStrings(p)
+	}
+	return paths, true
+}
+
+// equalIDs reports whether two lists of node ids are the same.
+func equalIDs(a, b []nodeID) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// lessPath orders paths by length, then by their node ids.
+func lessPath(a, b []nodeID) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return false
+}
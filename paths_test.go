@@ -0,0 +1,385 @@
+package diameter
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestShortestCycleThrough(t *testing.T) {
+	// A triangle a,b,c sharing node c with a square c,d,e,f, and a tail f-g.
+	el := edgeList{{"a", "b"}, {"b", "c"}, {"c", "a"}, {"c", "d"}, {"d", "e"}, {"e", "f"}, {"f", "c"}, {"f", "g"}}
+
+	tests := []struct {
+		name   string
+		node   string
+		expLen int
+		expOK  bool
+	}{
+		{
+			name:   "triangle",
+			node:   "a",
+			expLen: 3,
+			expOK:  true,
+		},
+		{
+			name:   "shared node",
+			node:   "c",
+			expLen: 3,
+			expOK:  true,
+		},
+		{
+			name:   "square",
+			node:   "e",
+			expLen: 4,
+			expOK:  true,
+		},
+		{
+			name: "tail",
+			node: "g",
+		},
+		{
+			name: "unknown",
+			node: "x",
+		},
+	}
+
+	g := New()
+	el.build(g)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cycle, ok := g.ShortestCycleThrough(test.node)
+			if ok != test.expOK {
+				t.Fatalf("Expected ok to be %t, have cycle %v", test.expOK, cycle)
+			}
+			if len(cycle) != test.expLen {
+				t.Fatalf("Cycle length not as expected. Have %d (%v), expected %d", len(cycle), cycle, test.expLen)
+			}
+			if !ok {
+				return
+			}
+			if cycle[0] != test.node {
+				t.Errorf("Cycle %v doesn't start at %s", cycle, test.node)
+			}
+			seen := make(map[string]bool)
+			for i, n := range cycle {
+				if seen[n] {
+					t.Errorf("Cycle %v visits %s twice", cycle, n)
+				}
+				seen[n] = true
+				next := cycle[(i+1)%len(cycle)]
+				if !g.nodes.adjacent(g.symbolTable.byName[nodeName(n)], g.symbolTable.byName[nodeName(next)]) {
+					t.Errorf("Cycle %v has no edge %s-%s", cycle, n, next)
+				}
+			}
+		})
+	}
+
+	t.Run("tree", func(t *testing.T) {
+		g := New()
+		edgeList{{"a", "b"}, {"b", "c"}, {"b", "d"}}.build(g)
+		for name := range g.symbolTable.byName {
+			if cycle, ok := g.ShortestCycleThrough(string(name)); ok {
+				t.Errorf("Expected no cycle through %s, have %v", name, cycle)
+			}
+		}
+	})
+}
+
+func TestPathThroughWaypoints(t *testing.T) {
+	el := edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}, {"x", "y"}}
+
+	tests := []struct {
+		name      string
+		waypoints []string
+		expPath   []string
+		expLen    int
+		expOK     bool
+	}{
+		{
+			name: "no waypoints",
+		},
+		{
+			name:      "single waypoint",
+			waypoints: []string{"b"},
+			expPath:   []string{"b"},
+			expOK:     true,
+		},
+		{
+			name:      "direct",
+			waypoints: []string{"a", "d"},
+			expPath:   []string{"a", "b", "c", "d"},
+			expLen:    3,
+			expOK:     true,
+		},
+		{
+			name:      "backtracking",
+			waypoints: []string{"a", "c", "b"},
+			expPath:   []string{"a", "b", "c", "b"},
+			expLen:    3,
+			expOK:     true,
+		},
+		{
+			name:      "repeated waypoint",
+			waypoints: []string{"a", "a", "b"},
+			expPath:   []string{"a", "b"},
+			expLen:    1,
+			expOK:     true,
+		},
+		{
+			name:      "unreachable",
+			waypoints: []string{"a", "b", "x"},
+		},
+		{
+			name:      "unknown",
+			waypoints: []string{"a", "unknown"},
+		},
+	}
+
+	g := New()
+	el.build(g)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path, length, ok := g.PathThroughWaypoints(test.waypoints)
+			if ok != test.expOK {
+				t.Fatalf("Expected ok to be %t", test.expOK)
+			}
+			if length != test.expLen {
+				t.Errorf("Length not as expected. Have %d, expected %d", length, test.expLen)
+			}
+			if len(path) != len(test.expPath) {
+				t.Fatalf("Path not as expected. Have %v, expected %v", path, test.expPath)
+			}
+			for i := range path {
+				if path[i] != test.expPath[i] {
+					t.Fatalf("Path not as expected. Have %v, expected %v", path, test.expPath)
+				}
+			}
+		})
+	}
+}
+
+func TestShortestPathAvoiding(t *testing.T) {
+	square := edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}, {"d", "a"}}
+
+	tests := []struct {
+		name     string
+		from, to string
+		avoid    [][2]string
+		expPath  []string
+		expOK    bool
+	}{
+		{
+			name:    "nothing avoided",
+			from:    "a",
+			to:      "b",
+			expPath: []string{"a", "b"},
+			expOK:   true,
+		},
+		{
+			name:    "detour",
+			from:    "a",
+			to:      "b",
+			avoid:   [][2]string{{"b", "a"}},
+			expPath: []string{"a", "d", "c", "b"},
+			expOK:   true,
+		},
+		{
+			name:  "cut off",
+			from:  "a",
+			to:    "b",
+			avoid: [][2]string{{"a", "b"}, {"c", "d"}},
+		},
+		{
+			name:    "unknown edge",
+			from:    "a",
+			to:      "b",
+			avoid:   [][2]string{{"a", "x"}},
+			expPath: []string{"a", "b"},
+			expOK:   true,
+		},
+		{
+			name: "unknown node",
+			from: "a",
+			to:   "x",
+		},
+	}
+
+	g := New()
+	square.build(g)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path, ok := g.ShortestPathAvoiding(test.from, test.to, test.avoid)
+			if ok != test.expOK {
+				t.Fatalf("Expected ok to be %t", test.expOK)
+			}
+			if len(path) != len(test.expPath) {
+				t.Fatalf("Path not as expected. Have %v, expected %v", path, test.expPath)
+			}
+			for i := range path {
+				if path[i] != test.expPath[i] {
+					t.Fatalf("Path not as expected. Have %v, expected %v", path, test.expPath)
+				}
+			}
+		})
+	}
+	if len(g.nodes.edges()) != len(square) {
+		t.Errorf("Graph was modified")
+	}
+}
+
+func TestKShortestPaths(t *testing.T) {
+	square := edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}, {"d", "a"}}
+	// Two routes of length 2 and one of length 3 from s to t.
+	routes := edgeList{{"s", "a"}, {"a", "t"}, {"s", "b"}, {"b", "t"}, {"s", "c"}, {"c", "d"}, {"d", "t"}}
+
+	tests := []struct {
+		name     string
+		edgeList edgeList
+		from, to string
+		k        int
+		exp      [][]string
+		expOK    bool
+	}{
+		{
+			name:     "Square k=1",
+			edgeList: square,
+			from:     "a",
+			to:       "b",
+			k:        1,
+			exp:      [][]string{{"a", "b"}},
+			expOK:    true,
+		},
+		{
+			name:     "Square k=2",
+			edgeList: square,
+			from:     "a",
+			to:       "b",
+			k:        2,
+			exp:      [][]string{{"a", "b"}, {"a", "d", "c", "b"}},
+			expOK:    true,
+		},
+		{
+			name:     "Square fewer than k",
+			edgeList: square,
+			from:     "a",
+			to:       "b",
+			k:        5,
+			exp:      [][]string{{"a", "b"}, {"a", "d", "c", "b"}},
+			expOK:    true,
+		},
+		{
+			name:     "routes",
+			edgeList: routes,
+			from:     "s",
+			to:       "t",
+			k:        3,
+			exp:      [][]string{{"s", "a", "t"}, {"s", "b", "t"}, {"s", "c", "d", "t"}},
+			expOK:    true,
+		},
+		{
+			name:     "disconnected",
+			edgeList: edgeList{{"a", "b"}, {"c", "d"}},
+			from:     "a",
+			to:       "c",
+			k:        2,
+		},
+		{
+			name:     "k=0",
+			edgeList: square,
+			from:     "a",
+			to:       "b",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := New()
+			test.edgeList.build(g)
+			paths, ok := g.KShortestPaths(test.from, test.to, test.k)
+			if ok != test.expOK {
+				t.Fatalf("Expected ok to be %t", test.expOK)
+			}
+			if fmt.Sprint(paths) != fmt.Sprint(test.exp) {
+				t.Errorf("Paths not as expected. Have %v, expected %v", paths, test.exp)
+			}
+		})
+	}
+}
+
+func TestShortestPath(t *testing.T) {
+	// A line a-b-c-d with a detour b-e-d of the same length as b-c-d, and a
+	// separate edge x-y.
+	el := edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}, {"x", "y"}, {"b", "e"}, {"e", "d"}}
+
+	tests := []struct {
+		name      string
+		from, to  string
+		expPath   []string
+		expLength int
+		expErr    error
+	}{
+		{
+			name:    "itself",
+			from:    "a",
+			to:      "a",
+			expPath: []string{"a"},
+		},
+		{
+			name:      "neighbor",
+			from:      "a",
+			to:        "b",
+			expPath:   []string{"a", "b"},
+			expLength: 1,
+		},
+		{
+			name:      "line",
+			from:      "a",
+			to:        "c",
+			expPath:   []string{"a", "b", "c"},
+			expLength: 2,
+		},
+		{
+			name:      "backwards",
+			from:      "d",
+			to:        "a",
+			expPath:   []string{"d", "c", "b", "a"},
+			expLength: 3,
+		},
+		{
+			name:   "disconnected",
+			from:   "a",
+			to:     "y",
+			expErr: ErrNoPath,
+		},
+		{
+			name:   "unknown start",
+			from:   "q",
+			to:     "a",
+			expErr: ErrNodeNotFound,
+		},
+		{
+			name:   "unknown end",
+			from:   "a",
+			to:     "q",
+			expErr: ErrNodeNotFound,
+		},
+	}
+
+	g := New()
+	el.build(g)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path, length, err := g.ShortestPath(test.from, test.to)
+			if !errors.Is(err, test.expErr) {
+				t.Fatalf("Error not as expected. Have %v, expected %v", err, test.expErr)
+			}
+			if length != test.expLength {
+				t.Errorf("Length not as expected. Have %d, expected %d", length, test.expLength)
+			}
+			if fmt.Sprint(path) != fmt.Sprint(test.expPath) {
+				t.Errorf("Path not as expected. Have %v, expected %v", path, test.expPath)
+			}
+		})
+	}
+}
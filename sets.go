@@ -0,0 +1,310 @@
+package diameter
+
+import "sort"
+
+// MaxIndependentSet returns a largest set of nodes of which no two are
+// adjacent. The set is found by an exact branch and bound search whose running
+// time is exponential in the number of nodes, so it is intended for small
+// graphs only.
+func (g *Graph) MaxIndependentSet() []string {
+	return g.symbolTable.toStrings(g.nodes.maxIndependentSet())
+}
+
+// maxIndependentSet returns the ids of a maximum independent set in ascending
+// order.
+func (nodes nodes) maxIndependentSet() []nodeID {
+	// A node with a self-loop is adjacent to itself and can never be part of
+	// an independent set.
+	var candidates []nodeID
+	for _, id := range nodes.ids() {
+		if !nodes.adjacent(id, id) {
+			candidates = append(candidates, id)
+		}
+	}
+
+	var best []nodeID
+	var search func(current, candidates []nodeID)
+	search = func(current, candidates []nodeID) {
+		// Bound: even taking every candidate can't beat the best set.
+		if len(current)+len(candidates) <= len(best) {
+			return
+		}
+
+		// Branch on the candidate with the most neighbors among the
+		// candidates, this prunes the search tree the quickest.
+		v, degree := nodeID(0), -1
+		for _, id := range candidates {
+			d := 0
+			for _, other := range candidates {
+				if other != id && nodes.adjacent(id, other) {
+					d++
+				}
+			}
+			if d > degree {
+				v, degree = id, d
+			}
+		}
+		if degree <= 0 {
+			// The remaining candidates are independent of each other.
+			best = append(append([]nodeID{}, current...), candidates...)
+			return
+		}
+
+		var without, rest []nodeID
+		for _, id := range candidates {
+			if id == v {
+				continue
+			}
+			without = append(without, id)
+			if !nodes.adjacent(v, id) {
+				rest = append(rest, id)
+			}
+		}
+
+		search(append(current, v), rest)
+		search(current, without)
+	}
+	search(nil, candidates)
+
+	sortIDs(best)
+	return best
+}
+
+// SubsetDensity returns the fraction of possible edges that are present
+// between the named nodes, i.e. the number of edges with both ends in the
+// subset divided by k(k-1)/2 for a subset of k nodes. Unknown and duplicate
+// names are ignored and self-loops don't count. A subset of less than two
+// nodes has a density of 0.
+func (g *Graph) SubsetDensity(names []string) float64 {
+	return g.nodes.density(g.lookupSet(names))
+}
+
+// density returns the fraction of possible edges present within the subset.
+func (nodes nodes) density(subset map[nodeID]bool) float64 {
+	k := len(subset)
+	if k < 2 {
+		return 0
+	}
+
+	var edges int
+	for id := range subset {
+		for adj := range nodes[id].adj {
+			if adj != id && subset[adj] {
+				edges++
+			}
+		}
+	}
+	// Every edge was counted from both of its ends.
+	return float64(edges) / float64(k*(k-1))
+}
+
+// lookupSet returns the ids of the named nodes present in the graph as a set.
+func (g *Graph) lookupSet(names []string) map[nodeID]bool {
+	set := make(map[nodeID]bool, len(names))
+	for _, name := range names {
+		if id, err := g.find(name); err == nil {
+			set[id] = true
+		}
+	}
+	return set
+}
+
+// Conductance returns the conductance of the cut separating the named nodes
+// from the rest of the graph: the number of edges crossing the cut divided by
+// the smaller of the two sides' volumes, where the volume is the sum of the
+// degrees of a side's nodes. A lower conductance means a better separated cut.
+// If either side has no edges at all the conductance is 0. Self-loops are
+// ignored.
+func (g *Graph) Conductance(side []string) float64 {
+	in := g.lookupSet(side)
+
+	var cut, volIn, volOut int
+	for id, n := range g.nodes {
+		if !in[id] {
+			volOut += g.nodes.degree(id)
+			continue
+		}
+		volIn += g.nodes.degree(id)
+		for adj := range n.adj {
+			if adj != id && !in[adj] {
+				cut++
+			}
+		}
+	}
+
+	vol := volIn
+	if volOut < vol {
+		vol = volOut
+	}
+	if vol == 0 {
+		return 0
+	}
+	return float64(cut) / float64(vol)
+}
+
+// greedyColoring colors the nodes in ascending id order, giving each node the
+// smallest color not used by its neighbors. It returns the colors, numbered
+// from 0, and the number of colors used. Self-loops are ignored.
+func (nodes nodes) greedyColoring() (map[nodeID]int, int) {
+	color := make(map[nodeID]int, len(nodes))
+	var count int
+	for _, id := range nodes.ids() {
+		used := make(map[int]bool)
+		for adj := range nodes[id].adj {
+			if c, ok := color[adj]; ok && adj != id {
+				used[c] = true
+			}
+		}
+		c := 0
+		for used[c] {
+			c++
+		}
+		color[id] = c
+		if c+1 > count {
+			count = c + 1
+		}
+	}
+	return color, count
+}
+
+// ChromaticNumber returns the smallest number of colors needed to color the
+// nodes so that no two adjacent nodes share a color. The greedy coloring
+// gives an upper bound, after which a backtracking search checks whether
+// fewer colors suffice. The search is exponential in the number of nodes, so
+// it is intended for small graphs. Self-loops are ignored.
+func (g *Graph) ChromaticNumber() int {
+	_, best := g.nodes.greedyColoring()
+	// Color the nodes with most neighbors first, they are the most
+	// constrained.
+	ids := g.nodes.ids()
+	sort.SliceStable(ids, func(i, j int) bool { return g.nodes.degree(ids[i]) > g.nodes.degree(ids[j]) })
+
+	for k := best - 1; k > 0; k-- {
+		if !g.nodes.colorable(ids, k) {
+			break
+		}
+		best = k
+	}
+	return best
+}
+
+// colorable reports whether the nodes can be colored with k colors, assigning
+// colors in the given order.
+func (nodes nodes) colorable(order []nodeID, k int) bool {
+	color := make(map[nodeID]int, len(order))
+	var assign func(i int) bool
+	assign = func(i int) bool {
+		if i == len(order) {
+			return true
+		}
+		id := order[i]
+	next:
+		for c := 0; c < k; c++ {
+			for adj := range nodes[id].adj {
+				if adj != id && color[adj] == c+1 {
+					continue next
+				}
+			}
+			// Colors are stored offset by one, 0 means uncolored.
+			color[id] = c + 1
+			if assign(i + 1) {
+				return true
+			}
+			delete(color, id)
+		}
+		return false
+	}
+	return assign(0)
+}
+
+// complement returns the complement of the graph on the same node ids: two
+// distinct nodes are adjacent in the complement iff they are not adjacent in
+// the graph. Self-loops are dropped.
+func (nodes nodes) complement() nodes {
+	c := make(map[nodeID]*node, len(nodes))
+	for id := range nodes {
+		c[id] = &node{
+			id:  id,
+			adj: make(map[nodeID]*node),
+		}
+	}
+	for a := range nodes {
+		for b := range nodes {
+			if a != b && !nodes.adjacent(a, b) {
+				c[a].add(c[b])
+			}
+		}
+	}
+	return c
+}
+
+// maxCliqueSize returns the size of the largest set of pairwise adjacent
+// nodes, found with the Bron–Kerbosch algorithm with pivoting.
+func (nodes nodes) maxCliqueSize() int {
+	var best int
+	var expand func(size int, candidates, excluded map[nodeID]bool)
+	expand = func(size int, candidates, excluded map[nodeID]bool) {
+		if len(candidates) == 0 {
+			if len(excluded) == 0 && size > best {
+				best = size
+			}
+			return
+		}
+		if size+len(candidates) <= best {
+			return
+		}
+
+		// Only branch on candidates that are not neighbors of the pivot.
+		var pivot nodeID
+		for id := range candidates {
+			pivot = id
+			break
+		}
+		var branch []nodeID
+		for id := range candidates {
+			if id == pivot || !nodes.adjacent(pivot, id) {
+				branch = append(branch, id)
+			}
+		}
+
+		for _, id := range branch {
+			nc := make(map[nodeID]bool)
+			ne := make(map[nodeID]bool)
+			for adj := range nodes[id].adj {
+				if adj == id {
+					continue
+				}
+				if candidates[adj] {
+					nc[adj] = true
+				}
+				if excluded[adj] {
+					ne[adj] = true
+				}
+			}
+			expand(size+1, nc, ne)
+			delete(candidates, id)
+			excluded[id] = true
+		}
+	}
+
+	candidates := make(map[nodeID]bool, len(nodes))
+	for id := range nodes {
+		candidates[id] = true
+	}
+	expand(0, candidates, make(map[nodeID]bool))
+	return best
+}
+
+// IndependenceNumber returns the size of the largest set of pairwise
+// non-adjacent nodes. It is computed as the size of the maximum clique of the
+// complement graph, which takes exponential time, so it is intended for small
+// graphs. Like in MaxIndependentSet, nodes with a self-loop never count.
+func (g *Graph) IndependenceNumber() int {
+	loopless := g.nodes.clone()
+	for id := range g.nodes {
+		if g.nodes.adjacent(id, id) {
+			loopless.removeNode(id)
+		}
+	}
+	return loopless.complement().maxCliqueSize()
+}
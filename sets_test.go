@@ -0,0 +1,288 @@
+package diameter
+
+import "testing"
+
+func TestMaxIndependentSet(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		edgeList edgeList
+		expSize  int
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:     "1 edge",
+			edgeList: edgeList{{"a", "b"}},
+			expSize:  1,
+		},
+		{
+			name:     "4 in line",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}},
+			expSize:  2,
+		},
+		{
+			name:     "Triangle",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"a", "c"}},
+			expSize:  1,
+		},
+		{
+			name:     "Square",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}, {"a", "d"}},
+			expSize:  2,
+		},
+		{
+			name:     "Star",
+			edgeList: edgeList{{"h", "a"}, {"h", "b"}, {"h", "c"}, {"h", "d"}},
+			expSize:  4,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := New()
+			test.edgeList.build(g)
+			set := g.MaxIndependentSet()
+			if len(set) != test.expSize {
+				t.Errorf("Size not as expected. Have %d (%v), expected %d", len(set), set, test.expSize)
+			}
+			for _, a := range set {
+				for _, b := range set {
+					if g.nodes.adjacent(g.symbolTable.byName[nodeName(a)], g.symbolTable.byName[nodeName(b)]) {
+						t.Errorf("Nodes %s and %s in the set are adjacent", a, b)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestSubsetDensity(t *testing.T) {
+	// A triangle a,b,c with a tail c-d-e.
+	el := edgeList{{"a", "b"}, {"b", "c"}, {"a", "c"}, {"c", "d"}, {"d", "e"}}
+
+	tests := []struct {
+		name       string
+		subset     []string
+		expDensity float64
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:   "single node",
+			subset: []string{"a"},
+		},
+		{
+			name:       "triangle",
+			subset:     []string{"a", "b", "c"},
+			expDensity: 1,
+		},
+		{
+			name:   "independent",
+			subset: []string{"a", "d"},
+		},
+		{
+			name:       "tail",
+			subset:     []string{"c", "d", "e"},
+			expDensity: 2.0 / 3.0,
+		},
+		{
+			name:       "unknown and duplicates",
+			subset:     []string{"a", "b", "b", "x"},
+			expDensity: 1,
+		},
+	}
+
+	g := New()
+	el.build(g)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d := g.SubsetDensity(test.subset)
+			if d != test.expDensity {
+				t.Errorf("Density not as expected. Have %f, expected %f", d, test.expDensity)
+			}
+		})
+	}
+}
+
+func TestConductance(t *testing.T) {
+	// Two triangles joined by the bridge c-d.
+	barbell := edgeList{{"a", "b"}, {"b", "c"}, {"a", "c"}, {"c", "d"}, {"d", "e"}, {"e", "f"}, {"d", "f"}}
+
+	tests := []struct {
+		name           string
+		side           []string
+		expConductance float64
+	}{
+		{
+			name: "empty side",
+		},
+		{
+			name: "whole graph",
+			side: []string{"a", "b", "c", "d", "e", "f"},
+		},
+		{
+			name:           "bridge",
+			side:           []string{"a", "b", "c"},
+			expConductance: 1.0 / 7.0,
+		},
+		{
+			name:           "single node",
+			side:           []string{"a"},
+			expConductance: 1,
+		},
+		{
+			name:           "across lobes",
+			side:           []string{"a", "b", "e", "f"},
+			expConductance: 4.0 / 6.0,
+		},
+	}
+
+	g := New()
+	barbell.build(g)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := g.Conductance(test.side)
+			if c != test.expConductance {
+				t.Errorf("Conductance not as expected. Have %f, expected %f", c, test.expConductance)
+			}
+		})
+	}
+
+	t.Run("self-loops", func(t *testing.T) {
+		g := New()
+		barbell.build(g)
+		edgeList{{"a", "a"}, {"f", "f"}}.build(g)
+		if c := g.Conductance([]string{"a", "b", "c"}); c != 1.0/7.0 {
+			t.Errorf("Conductance not as expected. Have %f, expected %f", c, 1.0/7.0)
+		}
+	})
+}
+
+func TestChromaticNumber(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		edgeList edgeList
+		exp      int
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:     "self-loop",
+			edgeList: edgeList{{"a", "a"}},
+			exp:      1,
+		},
+		{
+			name:     "1 edge",
+			edgeList: edgeList{{"a", "b"}},
+			exp:      2,
+		},
+		{
+			name:     "Triangle",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"a", "c"}},
+			exp:      3,
+		},
+		{
+			name:     "Square",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}, {"a", "d"}},
+			exp:      2,
+		},
+		{
+			name:     "Pentagon",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}, {"d", "e"}, {"e", "a"}},
+			exp:      3,
+		},
+		{
+			name:     "K4",
+			edgeList: edgeList{{"a", "b"}, {"a", "c"}, {"a", "d"}, {"b", "c"}, {"b", "d"}, {"c", "d"}},
+			exp:      4,
+		},
+		{
+			// A 6-cycle that the greedy coloring, in insertion order a, d, b,
+			// c, f, e, colors with 3 colors.
+			name:     "6-cycle",
+			edgeList: edgeList{{"a", "d"}, {"b", "c"}, {"c", "f"}, {"d", "e"}, {"e", "b"}, {"f", "a"}},
+			exp:      2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := New()
+			test.edgeList.build(g)
+			c := g.ChromaticNumber()
+			if c != test.exp {
+				t.Errorf("Chromatic number not as expected. Have %d, expected %d", c, test.exp)
+			}
+			if _, greedy := g.nodes.greedyColoring(); greedy < c {
+				t.Errorf("Greedy coloring uses %d colors, less than the chromatic number %d", greedy, c)
+			}
+		})
+	}
+}
+
+func TestIndependenceNumber(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		edgeList edgeList
+		exp      int
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:     "1 edge",
+			edgeList: edgeList{{"a", "b"}},
+			exp:      1,
+		},
+		{
+			name:     "Triangle",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"a", "c"}},
+			exp:      1,
+		},
+		{
+			name:     "Square",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}, {"a", "d"}},
+			exp:      2,
+		},
+		{
+			name:     "Pentagon",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}, {"d", "e"}, {"e", "a"}},
+			exp:      2,
+		},
+		{
+			name:     "Star",
+			edgeList: edgeList{{"h", "a"}, {"h", "b"}, {"h", "c"}, {"h", "d"}},
+			exp:      4,
+		},
+		{
+			name:     "self-loop",
+			edgeList: edgeList{{"a", "a"}, {"a", "b"}, {"b", "c"}},
+			exp:      1,
+		},
+		{
+			name:     "2 loops with tail",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"c", "a"}, {"c", "d"}, {"d", "e"}, {"e", "c"}, {"e", "f"}},
+			exp:      3,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := New()
+			test.edgeList.build(g)
+			n := g.IndependenceNumber()
+			if n != test.exp {
+				t.Errorf("Independence number not as expected. Have %d, expected %d", n, test.exp)
+			}
+			if mis := len(g.MaxIndependentSet()); mis != n {
+				t.Errorf("Independence number %d doesn't match the maximum independent set of size %d", n, mis)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package diameter
+
+import "math/rand"
+
+// averageClustering returns the mean local clustering coefficient of the
+// nodes. The local clustering coefficient of a node is the fraction of pairs
+// of its neighbors that are connected themselves, it is 0 for nodes with less
+// than two neighbors.
+func (nodes nodes) averageClustering() float64 {
+	if len(nodes) == 0 {
+		return 0
+	}
+	// Sum in a fixed order so the result doesn't depend on map iteration.
+	var sum float64
+	for _, id := range nodes.ids() {
+		var neighbors []nodeID
+		for adj := range nodes[id].adj {
+			if adj != id {
+				neighbors = append(neighbors, adj)
+			}
+		}
+		k := len(neighbors)
+		if k < 2 {
+			continue
+		}
+		var links int
+		for i, a := range neighbors {
+			for _, b := range neighbors[i+1:] {
+				if nodes.adjacent(a, b) {
+					links++
+				}
+			}
+		}
+		sum += float64(2*links) / float64(k*(k-1))
+	}
+	return sum / float64(len(nodes))
+}
+
+// randomNodes returns an Erdős–Rényi random graph of n nodes with m distinct
+// edges chosen uniformly at random, without self-loops. m is capped at the
+// number of possible edges.
+func randomNodes(n, m int, rnd *rand.Rand) nodes {
+	g := make(nodes, n)
+	for i := 0; i < n; i++ {
+		g.get(nodeID(i))
+	}
+	if max := n * (n - 1) / 2; m > max {
+		m = max
+	}
+	for added := 0; added < m; {
+		a, b := nodeID(rnd.Intn(n)), nodeID(rnd.Intn(n))
+		if a == b || g.adjacent(a, b) {
+			continue
+		}
+		g.addEdge(a, b)
+		added++
+	}
+	return g
+}
+
+// SmallWorldSigma returns the small-world coefficient σ = (C/Cr) / (L/Lr) of
+// the graph, where C is the average clustering coefficient, L the
+// characteristic path length and Cr and Lr are the same measures for an
+// Erdős–Rényi random graph with the same number of nodes and edges. The random
+// graph is generated from seed, so the same seed always gives the same σ. A
+// graph is considered small-world when σ is clearly above 1. It returns 0 when
+// any of the measures is 0, e.g. when the random graph has no triangles.
+func (g *Graph) SmallWorldSigma(seed int64) float64 {
+	n := len(g.nodes)
+	m := len(g.nodes.edges())
+	random := randomNodes(n, m, rand.New(rand.NewSource(seed)))
+
+	c, cr := g.nodes.averageClustering(), random.averageClustering()
+	l, lr := g.nodes.characteristicPathLength(), random.characteristicPathLength()
+	if c == 0 || cr == 0 || l == 0 || lr == 0 {
+		return 0
+	}
+	return (c / cr) / (l / lr)
+}
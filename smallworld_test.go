@@ -0,0 +1,66 @@
+package diameter
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestAverageClustering(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		edgeList edgeList
+		exp      float64
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:     "Triangle",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"a", "c"}},
+			exp:      1,
+		},
+		{
+			name:     "Square",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}, {"a", "d"}},
+		},
+		{
+			// a and b score 1, c scores 1/3 and d 0.
+			name:     "Triangle with tail",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"a", "c"}, {"c", "d"}},
+			exp:      (1 + 1 + 1.0/3.0) / 4,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := New()
+			test.edgeList.build(g)
+			c := g.nodes.averageClustering()
+			if math.Abs(c-test.exp) > epsilon {
+				t.Errorf("Clustering not as expected. Have %f, expected %f", c, test.exp)
+			}
+		})
+	}
+}
+
+func TestSmallWorldSigma(t *testing.T) {
+	// A ring lattice where every node is connected to its two nearest
+	// neighbors on both sides.
+	const n = 30
+	g := New()
+	for i := 0; i < n; i++ {
+		for _, j := range []int{(i + 1) % n, (i + 2) % n} {
+			g.AddEdge(fmt.Sprint(i), fmt.Sprint(j))
+		}
+	}
+
+	sigma := g.SmallWorldSigma(42)
+	if sigma <= 1 {
+		t.Errorf("Expected sigma of a ring lattice above 1, have %f", sigma)
+	}
+	if s := g.SmallWorldSigma(42); s != sigma {
+		t.Errorf("Sigma not reproducible with the same seed. Have %f and %f", s, sigma)
+	}
+}
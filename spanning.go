@@ -0,0 +1,190 @@
+package diameter
+
+import (
+	"errors"
+	"sort"
+)
+
+// nodePair is an undirected edge between two nodes identified by id.
+type nodePair [2]nodeID
+
+// newNodePair returns the edge between a and b with the lower id first.
+func newNodePair(a, b nodeID) nodePair {
+	if b < a {
+		return nodePair{b, a}
+	}
+	return nodePair{a, b}
+}
+
+// sortPairs sorts a list of edges in ascending order.
+func sortPairs(pairs []nodePair) {
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i][0] != pairs[j][0] {
+			return pairs[i][0] < pairs[j][0]
+		}
+		return pairs[i][1] < pairs[j][1]
+	})
+}
+
+// edges returns every edge of the graph once, with the lower id first, in
+// ascending order. Self-loops are left out.
+func (nodes nodes) edges() []nodePair {
+	var edges []nodePair
+	for _, id := range nodes.ids() {
+		adj := make([]nodeID, 0, len(nodes[id].adj))
+		for other := range nodes[id].adj {
+			if other > id {
+				adj = append(adj, other)
+			}
+		}
+		sortIDs(adj)
+		for _, other := range adj {
+			edges = append(edges, nodePair{id, other})
+		}
+	}
+	return edges
+}
+
+// edgesToStrings translates a list of edges into pairs of node names.
+func (s symbolTable) edgesToStrings(edges []nodePair) [][2]string {
+	names := s.names()
+	res := make([][2]string, len(edges))
+	for i, e := range edges {
+		res[i] = [2]string{string(names[e[0]]), string(names[e[1]])}
+	}
+	return res
+}
+
+// unionFind is a disjoint set forest over node ids.
+type unionFind map[nodeID]nodeID
+
+// find returns the representative of the set containing id.
+func (u unionFind) find(id nodeID) nodeID {
+	parent, ok := u[id]
+	if !ok || parent == id {
+		return id
+	}
+	root := u.find(parent)
+	u[id] = root
+	return root
+}
+
+// union merges the sets containing a and b. It returns false if they were
+// already in the same set.
+func (u unionFind) union(a, b nodeID) bool {
+	ra, rb := u.find(a), u.find(b)
+	if ra == rb {
+		return false
+	}
+	u[ra] = rb
+	return true
+}
+
+// clone returns an independent copy of the forest.
+func (u unionFind) clone() unionFind {
+	c := make(unionFind, len(u))
+	for k, v := range u {
+		c[k] = v
+	}
+	return c
+}
+
+// AllSpanningTrees returns every spanning tree of the graph, each as its list
+// of edges. The trees are enumerated by recursively contracting or deleting
+// each edge in turn, the number of trees grows exponentially with the size of
+// the graph so this is only feasible for small graphs. A disconnected graph
+// has no spanning trees.
+func (g *Graph) AllSpanningTrees() [][][2]string {
+	var trees [][][2]string
+	for _, tree := range g.nodes.spanningTrees() {
+		trees = append(trees, g.symbolTable.edgesToStrings(tree))
+	}
+	return trees
+}
+
+// spanningTrees enumerates the spanning trees of the graph.
+func (nodes nodes) spanningTrees() [][]nodePair {
+	if len(nodes) == 0 {
+		return nil
+	}
+	edges := nodes.edges()
+	need := len(nodes) - 1
+
+	var trees [][]nodePair
+	var enumerate func(i int, tree []nodePair, contracted unionFind)
+	enumerate = func(i int, tree []nodePair, contracted unionFind) {
+		if len(tree) == need {
+			trees = append(trees, append([]nodePair{}, tree...))
+			return
+		}
+		// Not enough edges left to complete the tree.
+		if need-len(tree) > len(edges)-i {
+			return
+		}
+
+		e := edges[i]
+		if contracted.find(e[0]) != contracted.find(e[1]) {
+			// Contract the edge, it becomes part of the tree.
+			c := contracted.clone()
+			c.union(e[0], e[1])
+			enumerate(i+1, append(tree, e), c)
+		}
+		// Delete the edge. An edge between nodes that are already contracted
+		// is a loop and must always be deleted.
+		enumerate(i+1, tree, contracted)
+	}
+	enumerate(0, nil, make(unionFind))
+
+	return trees
+}
+
+// ErrNotSpanningTree is returned when a graph given as spanning tree isn't a
+// spanning tree of the graph.
+var ErrNotSpanningTree = errors.New("diameter: not a spanning tree of the graph")
+
+// TreeStretch returns the average stretch of the spanning tree over all
+// unordered node pairs, where the stretch of a pair is the length of the path
+// between them in the tree divided by their distance in the graph. It is
+// always at least 1, with 1 meaning the tree preserves all distances. It
+// returns ErrNotSpanningTree if tree doesn't have exactly the graph's nodes or
+// isn't a tree made of the graph's edges.
+func (g *Graph) TreeStretch(tree *Graph) (float64, error) {
+	if len(tree.nodes) != len(g.nodes) || len(tree.nodes.edges()) != len(g.nodes)-1 {
+		return 0, ErrNotSpanningTree
+	}
+	// Map the tree's node ids to the graph's.
+	treeNames := tree.symbolTable.names()
+	toGraph := make(map[nodeID]nodeID, len(tree.nodes))
+	for id := range tree.nodes {
+		gid, err := g.find(string(treeNames[id]))
+		if err != nil {
+			return 0, ErrNotSpanningTree
+		}
+		toGraph[id] = gid
+	}
+	for _, e := range tree.nodes.edges() {
+		if !g.nodes.adjacent(toGraph[e[0]], toGraph[e[1]]) {
+			return 0, ErrNotSpanningTree
+		}
+	}
+	// n-1 edges and connected makes it a tree.
+	if _, sizes := tree.nodes.components(); len(sizes) > 1 {
+		return 0, ErrNotSpanningTree
+	}
+	if len(g.nodes) < 2 {
+		return 1, nil
+	}
+
+	var sum float64
+	var pairs int
+	for id := range tree.nodes {
+		graphDist := g.nodes.distances(toGraph[id])
+		for other, d := range tree.nodes.distances(id) {
+			if toGraph[other] > toGraph[id] {
+				sum += float64(d) / float64(graphDist[toGraph[other]])
+				pairs++
+			}
+		}
+	}
+	return sum / float64(pairs), nil
+}
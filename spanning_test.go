@@ -0,0 +1,134 @@
+package diameter
+
+import "testing"
+
+func TestAllSpanningTrees(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		edgeList edgeList
+		expCount int
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:     "1 edge",
+			edgeList: edgeList{{"a", "b"}},
+			expCount: 1,
+		},
+		{
+			name:     "4 in line",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}},
+			expCount: 1,
+		},
+		{
+			name:     "Triangle",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"a", "c"}},
+			expCount: 3,
+		},
+		{
+			name:     "Square",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}, {"a", "d"}},
+			expCount: 4,
+		},
+		{
+			// Cayley's formula n^(n-2).
+			name:     "K4",
+			edgeList: edgeList{{"a", "b"}, {"a", "c"}, {"a", "d"}, {"b", "c"}, {"b", "d"}, {"c", "d"}},
+			expCount: 16,
+		},
+		{
+			name:     "disconnected",
+			edgeList: edgeList{{"a", "b"}, {"c", "d"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := New()
+			test.edgeList.build(g)
+			trees := g.AllSpanningTrees()
+			if len(trees) != test.expCount {
+				t.Errorf("Number of spanning trees not as expected. Have %d, expected %d", len(trees), test.expCount)
+			}
+			for _, tree := range trees {
+				if len(tree) != len(g.nodes)-1 {
+					t.Errorf("Tree %v doesn't have %d edges", tree, len(g.nodes)-1)
+				}
+				u := make(unionFind)
+				for _, e := range tree {
+					if !u.union(g.symbolTable.byName[nodeName(e[0])], g.symbolTable.byName[nodeName(e[1])]) {
+						t.Errorf("Tree %v contains a cycle", tree)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestTreeStretch(t *testing.T) {
+	square := edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}, {"d", "a"}}
+
+	tests := []struct {
+		name   string
+		graph  edgeList
+		tree   edgeList
+		exp    float64
+		expErr error
+	}{
+		{
+			name:  "tree itself",
+			graph: edgeList{{"a", "b"}, {"b", "c"}},
+			tree:  edgeList{{"b", "c"}, {"a", "b"}},
+			exp:   1,
+		},
+		{
+			// Dropping d-a stretches a-d from 1 to 3, b-d and a-c keep
+			// their distance 2, the three other pairs remain edges.
+			name:  "Square",
+			graph: square,
+			tree:  edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}},
+			exp:   (3 + 5) / 6.0,
+		},
+		{
+			name:   "too few nodes",
+			graph:  square,
+			tree:   edgeList{{"a", "b"}, {"b", "c"}},
+			expErr: ErrNotSpanningTree,
+		},
+		{
+			name:   "not an edge of the graph",
+			graph:  square,
+			tree:   edgeList{{"a", "b"}, {"b", "c"}, {"a", "c"}, {"c", "d"}},
+			expErr: ErrNotSpanningTree,
+		},
+		{
+			name:   "cycle",
+			graph:  edgeList{{"a", "b"}, {"b", "c"}, {"c", "a"}, {"c", "d"}, {"d", "e"}, {"e", "f"}, {"f", "d"}},
+			tree:   edgeList{{"a", "b"}, {"b", "c"}, {"c", "a"}, {"d", "e"}, {"e", "f"}},
+			expErr: ErrNotSpanningTree,
+		},
+		{
+			name:   "foreign node",
+			graph:  square,
+			tree:   edgeList{{"a", "b"}, {"b", "c"}, {"c", "x"}},
+			expErr: ErrNotSpanningTree,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g, tree := New(), New()
+			test.graph.build(g)
+			test.tree.build(tree)
+			s, err := g.TreeStretch(tree)
+			if err != test.expErr {
+				t.Fatalf("Error not as expected. Have %v, expected %v", err, test.expErr)
+			}
+			if s != test.exp {
+				t.Errorf("Stretch not as expected. Have %f, expected %f", s, test.exp)
+			}
+		})
+	}
+}
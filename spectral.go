@@ -0,0 +1,390 @@
+package diameter
+
+import (
+	"math"
+	"sort"
+)
+
+// matrix is a dense square matrix of float64 values, stored by row.
+type matrix [][]float64
+
+// newMatrix returns an n by n matrix of zeros.
+func newMatrix(n int) matrix {
+	m := make(matrix, n)
+	for i := range m {
+		m[i] = make([]float64, n)
+	}
+	return m
+}
+
+// index maps every node id to its row in a matrix representation of the
+// graph, nodes are ordered by ascending id.
+func (nodes nodes) index() ([]nodeID, map[nodeID]int) {
+	ids := nodes.ids()
+	index := make(map[nodeID]int, len(ids))
+	for i, id := range ids {
+		index[id] = i
+	}
+	return ids, index
+}
+
+// adjacencyMatrix returns the adjacency matrix of the graph with rows ordered
+// by ascending node id.
+func (nodes nodes) adjacencyMatrix() matrix {
+	ids, index := nodes.index()
+	a := newMatrix(len(ids))
+	for i, id := range ids {
+		for adj := range nodes[id].adj {
+			a[i][index[adj]] = 1
+		}
+	}
+	return a
+}
+
+// laplacianMatrix returns the Laplacian D - A of the graph with rows ordered by
+// ascending node id. Self-loops don't contribute to the Laplacian.
+func (nodes nodes) laplacianMatrix() matrix {
+	ids, index := nodes.index()
+	l := newMatrix(len(ids))
+	for i, id := range ids {
+		for adj := range nodes[id].adj {
+			if adj == id {
+				continue
+			}
+			l[i][index[adj]] = -1
+			l[i][i]++
+		}
+	}
+	return l
+}
+
+// Tuning of the Jacobi eigenvalue iteration.
+const (
+	// jacobiTolerance is the size of the off-diagonal elements below which
+	// the matrix is considered diagonal.
+	jacobiTolerance = 1e-12
+	// jacobiMaxSweeps bounds the number of sweeps over the matrix.
+	jacobiMaxSweeps = 100
+)
+
+// symmetricEigen computes the eigenvalues and eigenvectors of the symmetric
+// matrix a with the cyclic Jacobi method. The eigenvalues are returned in
+// ascending order and the i-th column of the returned matrix is the unit
+// eigenvector belonging to the i-th eigenvalue. a is left unchanged.
+func symmetricEigen(a matrix) ([]float64, matrix) {
+	n := len(a)
+	m := newMatrix(n)
+	v := newMatrix(n)
+	for i := range a {
+		copy(m[i], a[i])
+		v[i][i] = 1
+	}
+
+	for sweep := 0; sweep < jacobiMaxSweeps; sweep++ {
+		var off float64
+		for p := 0; p < n; p++ {
+			for q := p + 1; q < n; q++ {
+				off += m[p][q] * m[p][q]
+			}
+		}
+		if off < jacobiTolerance*jacobiTolerance {
+			break
+		}
+
+		for p := 0; p < n; p++ {
+			for q := p + 1; q < n; q++ {
+				if math.Abs(m[p][q]) < jacobiTolerance*jacobiTolerance {
+					continue
+				}
+				// Rotate rows and columns p and q to zero m[p][q].
+				theta := (m[q][q] - m[p][p]) / (2 * m[p][q])
+				t := 1 / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+				if theta < 0 {
+					t = -t
+				}
+				c := 1 / math.Sqrt(t*t+1)
+				s := t * c
+
+				for k := 0; k < n; k++ {
+					mkp, mkq := m[k][p], m[k][q]
+					m[k][p] = c*mkp - s*mkq
+					m[k][q] = s*mkp + c*mkq
+				}
+				for k := 0; k < n; k++ {
+					mpk, mqk := m[p][k], m[q][k]
+					m[p][k] = c*mpk - s*mqk
+					m[q][k] = s*mpk + c*mqk
+				}
+				for k := 0; k < n; k++ {
+					vkp, vkq := v[k][p], v[k][q]
+					v[k][p] = c*vkp - s*vkq
+					v[k][q] = s*vkp + c*vkq
+				}
+			}
+		}
+	}
+
+	// Sort the eigenpairs by eigenvalue.
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return m[order[i]][order[i]] < m[order[j]][order[j]] })
+
+	values := make([]float64, n)
+	vectors := newMatrix(n)
+	for j, o := range order {
+		values[j] = m[o][o]
+		for i := 0; i < n; i++ {
+			vectors[i][j] = v[i][o]
+		}
+	}
+	return values, vectors
+}
+
+// NaturalConnectivity returns the natural connectivity of the graph, the
+// natural logarithm of the mean of e^λ over the eigenvalues λ of the adjacency
+// matrix. It increases strictly with every added edge, which makes it a finer
+// measure of robustness than connectivity itself. The eigenvalues are computed
+// with a dense eigensolver, so it is intended for small to medium graphs. The
+// empty graph has a natural connectivity of 0.
+func (g *Graph) NaturalConnectivity() float64 {
+	if len(g.nodes) == 0 {
+		return 0
+	}
+	values, _ := symmetricEigen(g.nodes.adjacencyMatrix())
+
+	// Factor out the largest eigenvalue so the exponentials can't overflow.
+	max := values[len(values)-1]
+	var sum float64
+	for _, l := range values {
+		sum += math.Exp(l - max)
+	}
+	return max + math.Log(sum/float64(len(values)))
+}
+
+// zeroEigenvalue is the magnitude below which a computed eigenvalue is
+// considered to be 0, absorbing the rounding errors of the eigensolver.
+const zeroEigenvalue = 1e-9
+
+// laplacianSpectrum returns the eigenvalues of the Laplacian in ascending
+// order, with values indistinguishable from 0 set to exactly 0.
+func (nodes nodes) laplacianSpectrum() []float64 {
+	values, _ := symmetricEigen(nodes.laplacianMatrix())
+	for i, l := range values {
+		if math.Abs(l) < zeroEigenvalue {
+			values[i] = 0
+		}
+	}
+	return values
+}
+
+// AlgebraicConnectivity returns the Fiedler value of the graph, the second
+// smallest eigenvalue of its Laplacian. It is 0 exactly when the graph is
+// disconnected and grows the harder the graph is to cut apart. A graph with
+// less than two nodes has an algebraic connectivity of 0.
+func (g *Graph) AlgebraicConnectivity() float64 {
+	if len(g.nodes) < 2 {
+		return 0
+	}
+	return g.nodes.laplacianSpectrum()[1]
+}
+
+// Tuning of the power iteration for the spectral radius.
+const (
+	// powerTolerance is the change in the eigenvalue estimate between two
+	// iterations below which the iteration has converged.
+	powerTolerance = 1e-10
+	// powerMaxIterations bounds the number of iterations.
+	powerMaxIterations = 10000
+)
+
+// SpectralRadius returns the largest eigenvalue of the adjacency matrix. It is
+// computed by power iteration directly on the adjacency lists, no matrix is
+// built. The iteration runs on A+I, which has the same dominant eigenvector,
+// so it also converges for bipartite graphs where the spectrum of A is
+// symmetric around 0. It stops when the estimate changes less than
+// powerTolerance or after powerMaxIterations. For a k-regular graph the
+// spectral radius is k.
+func (g *Graph) SpectralRadius() float64 {
+	ids, index := g.nodes.index()
+	if len(ids) == 0 {
+		return 0
+	}
+
+	x := make([]float64, len(ids))
+	for i := range x {
+		x[i] = 1 / math.Sqrt(float64(len(x)))
+	}
+	y := make([]float64, len(ids))
+
+	var lambda float64
+	for iter := 0; iter < powerMaxIterations; iter++ {
+		// y = (A+I)x
+		for i, id := range ids {
+			y[i] = x[i]
+			for adj := range g.nodes[id].adj {
+				y[i] += x[index[adj]]
+			}
+		}
+
+		// The Rayleigh quotient of the unit vector x estimates the eigenvalue.
+		var rq, norm float64
+		for i := range x {
+			rq += x[i] * y[i]
+			norm += y[i] * y[i]
+		}
+		norm = math.Sqrt(norm)
+		if norm == 0 {
+			return 0
+		}
+		for i := range y {
+			x[i] = y[i] / norm
+		}
+
+		converged := math.Abs(rq-1-lambda) < powerTolerance
+		lambda = rq - 1
+		if converged {
+			break
+		}
+	}
+	return lambda
+}
+
+// laplacianPseudoinverse returns the Moore-Penrose pseudoinverse of the
+// Laplacian, the sum of v·vᵀ/λ over its nonzero eigenvalues λ with
+// eigenvectors v. Rows are ordered by ascending node id, the index maps node
+// ids to rows.
+func (nodes nodes) laplacianPseudoinverse() (matrix, map[nodeID]int) {
+	_, index := nodes.index()
+	values, vectors := symmetricEigen(nodes.laplacianMatrix())
+	n := len(values)
+	pinv := newMatrix(n)
+	for k, l := range values {
+		if math.Abs(l) < zeroEigenvalue {
+			continue
+		}
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				pinv[i][j] += vectors[i][k] * vectors[j][k] / l
+			}
+		}
+	}
+	return pinv, index
+}
+
+// ResistanceDistance returns the effective resistance between node a and b
+// when every edge is a unit resistor. It is computed from the pseudoinverse
+// L⁺ of the Laplacian as L⁺aa + L⁺bb - 2L⁺ab. The resistance is never more
+// than the shortest path length and drops with every additional path between
+// the nodes. It returns false if either node is unknown or they are not
+// connected.
+func (g *Graph) ResistanceDistance(a, b string) (float64, bool) {
+	from, err := g.find(a)
+	if err != nil {
+		return 0, false
+	}
+	to, err := g.find(b)
+	if err != nil {
+		return 0, false
+	}
+	if !g.nodes.reachable(from, to, func(_, _ nodeID) bool { return false }) {
+		return 0, false
+	}
+	if from == to {
+		return 0, true
+	}
+
+	pinv, index := g.nodes.laplacianPseudoinverse()
+	i, j := index[from], index[to]
+	return pinv[i][i] + pinv[j][j] - 2*pinv[i][j], true
+}
+
+// KirchhoffIndex returns the Kirchhoff index of the graph, the sum of the
+// resistance distances over all unordered node pairs. It is computed from the
+// Laplacian spectrum as n times the sum of 1/λ over the nonzero eigenvalues λ.
+// It returns ErrDisconnected if the graph is disconnected, as the index is
+// infinite then.
+func (g *Graph) KirchhoffIndex() (float64, error) {
+	if len(g.nodes) == 0 {
+		return 0, nil
+	}
+	values := g.nodes.laplacianSpectrum()
+	// The first eigenvalue is always 0, a second one means a second
+	// component.
+	if len(values) > 1 && values[1] == 0 {
+		return 0, ErrDisconnected
+	}
+
+	var sum float64
+	for _, l := range values[1:] {
+		sum += 1 / l
+	}
+	return float64(len(values)) * sum, nil
+}
+
+// EdgeSpanningTreeProbabilities returns for every edge the probability that
+// it is part of a spanning tree drawn uniformly at random. By Kirchhoff's
+// theorem this is the edge's effective resistance times its conductance, here
+// 1, and the resistances are read from the Laplacian pseudoinverse. Edges are
+// keyed by their node names in the order the nodes were added to the graph.
+// The probabilities sum up to the number of nodes minus one. It returns
+// ErrDisconnected if the graph is disconnected, as it has no spanning trees.
+func (g *Graph) EdgeSpanningTreeProbabilities() (map[[2]string]float64, error) {
+	if _, sizes := g.nodes.components(); len(sizes) > 1 {
+		return nil, ErrDisconnected
+	}
+
+	pinv, index := g.nodes.laplacianPseudoinverse()
+	edges := g.nodes.edges()
+	names := g.symbolTable.edgesToStrings(edges)
+	probs := make(map[[2]string]float64, len(edges))
+	for k, e := range edges {
+		i, j := index[e[0]], index[e[1]]
+		probs[names[k]] = pinv[i][i] + pinv[j][j] - 2*pinv[i][j]
+	}
+	return probs, nil
+}
+
+// SpectralGap returns the difference between the two largest eigenvalues of
+// the random walk transition matrix D⁻¹A. The largest is always 1, the larger
+// the gap the faster a random walk forgets where it started. A disconnected
+// graph has a gap of 0. The eigenvalues are taken from the symmetric matrix
+// D^(-1/2)·A·D^(-1/2), which has the same spectrum. Nodes without neighbors
+// are left out, a walk can't move there. A graph with less than two such
+// nodes has a gap of 0.
+func (g *Graph) SpectralGap() float64 {
+	a := g.nodes.adjacencyMatrix()
+	var rows []int
+	for i := range a {
+		for _, v := range a[i] {
+			if v != 0 {
+				rows = append(rows, i)
+				break
+			}
+		}
+	}
+	if len(rows) < 2 {
+		return 0
+	}
+
+	degree := make([]float64, len(rows))
+	for k, i := range rows {
+		for _, v := range a[i] {
+			degree[k] += v
+		}
+	}
+	n := newMatrix(len(rows))
+	for k, i := range rows {
+		for l, j := range rows {
+			n[k][l] = a[i][j] / math.Sqrt(degree[k]*degree[l])
+		}
+	}
+
+	values, _ := symmetricEigen(n)
+	gap := values[len(values)-1] - values[len(values)-2]
+	if gap < zeroEigenvalue {
+		return 0
+	}
+	return gap
+}
@@ -0,0 +1,457 @@
+package diameter
+
+import (
+	"math"
+	"testing"
+)
+
+// epsilon is the tolerance when comparing computed floating point values.
+const epsilon = 1e-9
+
+func TestSymmetricEigen(t *testing.T) {
+	a := matrix{
+		{2, -1, 0},
+		{-1, 2, -1},
+		{0, -1, 2},
+	}
+	values, vectors := symmetricEigen(a)
+
+	exp := []float64{2 - math.Sqrt2, 2, 2 + math.Sqrt2}
+	for i := range exp {
+		if math.Abs(values[i]-exp[i]) > epsilon {
+			t.Errorf("Eigenvalue %d not as expected. Have %f, expected %f", i, values[i], exp[i])
+		}
+		// A v = λ v
+		for r := range a {
+			var av float64
+			for c := range a {
+				av += a[r][c] * vectors[c][i]
+			}
+			if math.Abs(av-values[i]*vectors[r][i]) > epsilon {
+				t.Errorf("Column %d is not an eigenvector", i)
+			}
+		}
+	}
+}
+
+func TestNaturalConnectivity(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		edgeList edgeList
+		exp      float64
+	}{
+		{
+			name: "empty",
+		},
+		{
+			// Eigenvalues -1 and 1.
+			name:     "1 edge",
+			edgeList: edgeList{{"a", "b"}},
+			exp:      math.Log(math.Cosh(1)),
+		},
+		{
+			// Eigenvalues -1, -1 and 2.
+			name:     "Triangle",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"a", "c"}},
+			exp:      math.Log((2*math.Exp(-1) + math.Exp(2)) / 3),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := New()
+			test.edgeList.build(g)
+			nc := g.NaturalConnectivity()
+			if math.Abs(nc-test.exp) > epsilon {
+				t.Errorf("Natural connectivity not as expected. Have %f, expected %f", nc, test.exp)
+			}
+		})
+	}
+
+	t.Run("denser core", func(t *testing.T) {
+		// Both graphs have 5 nodes and 5 edges.
+		cycle := New()
+		edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}, {"d", "e"}, {"e", "a"}}.build(cycle)
+		core := New()
+		edgeList{{"a", "b"}, {"b", "c"}, {"c", "a"}, {"c", "d"}, {"c", "e"}}.build(core)
+
+		if cycle.NaturalConnectivity() >= core.NaturalConnectivity() {
+			t.Errorf("Expected the graph with a triangle core to be more robust. Have %f for the cycle and %f for the core",
+				cycle.NaturalConnectivity(), core.NaturalConnectivity())
+		}
+	})
+}
+
+func TestAlgebraicConnectivity(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		edgeList edgeList
+		exp      float64
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:     "1 edge",
+			edgeList: edgeList{{"a", "b"}},
+			exp:      2,
+		},
+		{
+			name:     "3 in line",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}},
+			exp:      1,
+		},
+		{
+			name:     "Triangle",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"a", "c"}},
+			exp:      3,
+		},
+		{
+			name:     "Square",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}, {"a", "d"}},
+			exp:      2,
+		},
+		{
+			name:     "two disjoint edges",
+			edgeList: edgeList{{"a", "b"}, {"c", "d"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := New()
+			test.edgeList.build(g)
+			ac := g.AlgebraicConnectivity()
+			if math.Abs(ac-test.exp) > epsilon {
+				t.Errorf("Algebraic connectivity not as expected. Have %f, expected %f", ac, test.exp)
+			}
+			if (ac > 0) != (test.exp > 0) {
+				t.Errorf("Algebraic connectivity %g doesn't match connectivity", ac)
+			}
+		})
+	}
+}
+
+func TestSpectralRadius(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		edgeList edgeList
+		exp      float64
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:     "1 edge",
+			edgeList: edgeList{{"a", "b"}},
+			exp:      1,
+		},
+		{
+			name:     "Triangle",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"a", "c"}},
+			exp:      2,
+		},
+		{
+			name:     "Square",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}, {"a", "d"}},
+			exp:      2,
+		},
+		{
+			name:     "Star",
+			edgeList: edgeList{{"h", "a"}, {"h", "b"}, {"h", "c"}, {"h", "d"}},
+			exp:      2,
+		},
+		{
+			name:     "3 in line",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}},
+			exp:      math.Sqrt2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := New()
+			test.edgeList.build(g)
+			r := g.SpectralRadius()
+			if math.Abs(r-test.exp) > 1e-6 {
+				t.Errorf("Spectral radius not as expected. Have %f, expected %f", r, test.exp)
+			}
+		})
+	}
+}
+
+func TestResistanceDistance(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		edgeList edgeList
+		a, b     string
+		exp      float64
+		expOK    bool
+	}{
+		{
+			name:     "1 edge",
+			edgeList: edgeList{{"a", "b"}},
+			a:        "a",
+			b:        "b",
+			exp:      1,
+			expOK:    true,
+		},
+		{
+			name:     "series",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}},
+			a:        "a",
+			b:        "d",
+			exp:      3,
+			expOK:    true,
+		},
+		{
+			name:     "Square opposite corners",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}, {"a", "d"}},
+			a:        "a",
+			b:        "c",
+			exp:      1,
+			expOK:    true,
+		},
+		{
+			// 1 in parallel with 3.
+			name:     "Square adjacent corners",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}, {"a", "d"}},
+			a:        "a",
+			b:        "b",
+			exp:      0.75,
+			expOK:    true,
+		},
+		{
+			name:     "Triangle",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"a", "c"}},
+			a:        "a",
+			b:        "b",
+			exp:      2.0 / 3.0,
+			expOK:    true,
+		},
+		{
+			name:     "same node",
+			edgeList: edgeList{{"a", "b"}},
+			a:        "a",
+			b:        "a",
+			expOK:    true,
+		},
+		{
+			name:     "disconnected",
+			edgeList: edgeList{{"a", "b"}, {"c", "d"}},
+			a:        "a",
+			b:        "c",
+		},
+		{
+			name:     "unknown",
+			edgeList: edgeList{{"a", "b"}},
+			a:        "a",
+			b:        "x",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := New()
+			test.edgeList.build(g)
+			r, ok := g.ResistanceDistance(test.a, test.b)
+			if ok != test.expOK {
+				t.Fatalf("Expected ok to be %t", test.expOK)
+			}
+			if math.Abs(r-test.exp) > epsilon {
+				t.Errorf("Resistance not as expected. Have %f, expected %f", r, test.exp)
+			}
+		})
+	}
+}
+
+func TestKirchhoffIndex(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		edgeList edgeList
+		exp      float64
+		expErr   error
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:     "1 edge",
+			edgeList: edgeList{{"a", "b"}},
+			exp:      1,
+		},
+		{
+			// Eigenvalues 0, 3 and 3.
+			name:     "Triangle",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"a", "c"}},
+			exp:      2,
+		},
+		{
+			// For a tree it equals the Wiener index.
+			name:     "4 in line",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}},
+			exp:      10,
+		},
+		{
+			// Four sides of 3/4 and two diagonals of 1.
+			name:     "Square",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}, {"a", "d"}},
+			exp:      5,
+		},
+		{
+			name:     "disconnected",
+			edgeList: edgeList{{"a", "b"}, {"c", "d"}},
+			expErr:   ErrDisconnected,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := New()
+			test.edgeList.build(g)
+			k, err := g.KirchhoffIndex()
+			if err != test.expErr {
+				t.Fatalf("Error not as expected. Have %v, expected %v", err, test.expErr)
+			}
+			if math.Abs(k-test.exp) > epsilon {
+				t.Errorf("Kirchhoff index not as expected. Have %f, expected %f", k, test.exp)
+			}
+		})
+	}
+}
+
+func TestEdgeSpanningTreeProbabilities(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		edgeList edgeList
+		exp      map[[2]string]float64
+		expErr   error
+	}{
+		{
+			name: "empty",
+			exp:  map[[2]string]float64{},
+		},
+		{
+			name:     "Triangle",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"a", "c"}},
+			exp:      map[[2]string]float64{{"a", "b"}: 2.0 / 3.0, {"b", "c"}: 2.0 / 3.0, {"a", "c"}: 2.0 / 3.0},
+		},
+		{
+			name:     "tree",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"b", "d"}},
+			exp:      map[[2]string]float64{{"a", "b"}: 1, {"b", "c"}: 1, {"b", "d"}: 1},
+		},
+		{
+			name:     "Triangle with tail",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"a", "c"}, {"c", "d"}},
+			exp: map[[2]string]float64{
+				{"a", "b"}: 2.0 / 3.0, {"b", "c"}: 2.0 / 3.0, {"a", "c"}: 2.0 / 3.0, {"c", "d"}: 1,
+			},
+		},
+		{
+			name:     "disconnected",
+			edgeList: edgeList{{"a", "b"}, {"c", "d"}},
+			expErr:   ErrDisconnected,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := New()
+			test.edgeList.build(g)
+			probs, err := g.EdgeSpanningTreeProbabilities()
+			if err != test.expErr {
+				t.Fatalf("Error not as expected. Have %v, expected %v", err, test.expErr)
+			}
+			if len(probs) != len(test.exp) {
+				t.Errorf("Number of edges not as expected. Have %d, expected %d", len(probs), len(test.exp))
+			}
+			var sum float64
+			for e, exp := range test.exp {
+				if math.Abs(probs[e]-exp) > epsilon {
+					t.Errorf("Probability of %v not as expected. Have %f, expected %f", e, probs[e], exp)
+				}
+				sum += probs[e]
+			}
+			if len(probs) > 0 && math.Abs(sum-float64(len(g.nodes)-1)) > epsilon {
+				t.Errorf("Probabilities sum up to %f, expected %d", sum, len(g.nodes)-1)
+			}
+		})
+	}
+}
+
+func TestSpectralGap(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		edgeList edgeList
+		exp      float64
+	}{
+		{
+			name: "empty",
+		},
+		{
+			// Eigenvalues 1 and -1.
+			name:     "1 edge",
+			edgeList: edgeList{{"a", "b"}},
+			exp:      2,
+		},
+		{
+			// Eigenvalues 1, -1/3, -1/3 and -1/3.
+			name:     "K4",
+			edgeList: edgeList{{"a", "b"}, {"a", "c"}, {"a", "d"}, {"b", "c"}, {"b", "d"}, {"c", "d"}},
+			exp:      4.0 / 3.0,
+		},
+		{
+			// Eigenvalues 1, 0, 0 and -1.
+			name:     "Square",
+			edgeList: edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}, {"a", "d"}},
+			exp:      1,
+		},
+		{
+			name:     "two disjoint edges",
+			edgeList: edgeList{{"a", "b"}, {"c", "d"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := New()
+			test.edgeList.build(g)
+			gap := g.SpectralGap()
+			if math.Abs(gap-test.exp) > epsilon {
+				t.Errorf("Spectral gap not as expected. Have %f, expected %f", gap, test.exp)
+			}
+		})
+	}
+
+	t.Run("barbell", func(t *testing.T) {
+		// Two K4 joined by the single edge d-e.
+		barbell := New()
+		edgeList{
+			{"a", "b"}, {"a", "c"}, {"a", "d"}, {"b", "c"}, {"b", "d"}, {"c", "d"},
+			{"d", "e"},
+			{"e", "f"}, {"e", "g"}, {"e", "h"}, {"f", "g"}, {"f", "h"}, {"g", "h"},
+		}.build(barbell)
+		// The complete graph on the same 8 nodes.
+		complete := New()
+		for _, a := range "abcdefgh" {
+			for _, b := range "abcdefgh" {
+				if a < b {
+					complete.AddEdge(string(a), string(b))
+				}
+			}
+		}
+
+		if barbell.SpectralGap() >= complete.SpectralGap() {
+			t.Errorf("Expected the barbell to mix slower. Have gap %f for the barbell and %f for the complete graph",
+				barbell.SpectralGap(), complete.SpectralGap())
+		}
+	})
+}
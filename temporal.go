@@ -0,0 +1,80 @@
+package diameter
+
+import (
+	"math"
+	"sort"
+)
+
+// AddTimedEdge adds a connection between node a and b that is active at time
+// t. The edge is added to the graph like any other edge, the timestamp is
+// only used by the temporal computations. An edge can be added with several
+// timestamps, it is then active at each of them.
+func (g *Graph) AddTimedEdge(a, b string, t int64) {
+	g.AddEdge(a, b)
+	e := newNodePair(g.symbolTable.byName[nodeName(a)], g.symbolTable.byName[nodeName(b)])
+	times := g.times[e]
+	i := sort.Search(len(times), func(i int) bool { return times[i] >= t })
+	if i < len(times) && times[i] == t {
+		return
+	}
+	times = append(times, 0)
+	copy(times[i+1:], times[i:])
+	times[i] = t
+	g.times[e] = times
+}
+
+// TemporalDiameter returns the largest temporal distance between any two
+// nodes that can reach each other over the timed edges.
+//
+// A time-respecting path is a path over timed edges, in either direction,
+// whose timestamps never decrease along the path; it may wait at a node but
+// can't go back in time. The temporal distance from u to v is the smallest
+// number of edges on a time-respecting path from u to v. It is not symmetric:
+// v may not be able to reach u at all. Edges added without a timestamp are
+// ignored.
+//
+// For every start node it computes, for increasing numbers of hops, the
+// earliest time every node can be reached within that many hops. Arriving
+// earlier never hurts, so a node is reachable in k hops exactly when it has
+// an earliest arrival time after k rounds.
+func (g *Graph) TemporalDiameter() int {
+	var diameter int
+	for start := range g.nodes {
+		arrival := map[nodeID]int64{start: math.MinInt64}
+		for hops := 1; hops < len(g.nodes); hops++ {
+			next := make(map[nodeID]int64, len(arrival))
+			for id, t := range arrival {
+				next[id] = t
+			}
+			changed := false
+			for e, times := range g.times {
+				for _, dir := range [2][2]nodeID{{e[0], e[1]}, {e[1], e[0]}} {
+					from, to := dir[0], dir[1]
+					at, ok := arrival[from]
+					if !ok {
+						continue
+					}
+					// The first time the edge is active after arriving.
+					i := sort.Search(len(times), func(i int) bool { return times[i] >= at })
+					if i == len(times) {
+						continue
+					}
+					if t, ok := next[to]; !ok || times[i] < t {
+						next[to] = times[i]
+						changed = true
+					}
+				}
+			}
+			// Even without newly reached nodes, earlier arrivals can open
+			// up new nodes in the next round.
+			if !changed {
+				break
+			}
+			if len(next) > len(arrival) && hops > diameter {
+				diameter = hops
+			}
+			arrival = next
+		}
+	}
+	return diameter
+}
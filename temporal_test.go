@@ -0,0 +1,68 @@
+package diameter
+
+import "testing"
+
+type timedEdge struct {
+	a, b string
+	t    int64
+}
+
+func TestTemporalDiameter(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		edges  []timedEdge
+		expDia int
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:   "1 edge",
+			edges:  []timedEdge{{"a", "b", 1}},
+			expDia: 1,
+		},
+		{
+			name:   "in order",
+			edges:  []timedEdge{{"a", "b", 1}, {"b", "c", 2}, {"c", "d", 3}},
+			expDia: 3,
+		},
+		{
+			// b-c happens before a-b, so a can't reach c or d. The
+			// longest temporal distances are b to d and c to a.
+			name:   "out of order",
+			edges:  []timedEdge{{"a", "b", 2}, {"b", "c", 1}, {"c", "d", 3}},
+			expDia: 2,
+		},
+		{
+			name:   "equal times",
+			edges:  []timedEdge{{"a", "b", 1}, {"b", "c", 1}},
+			expDia: 2,
+		},
+		{
+			// The direct contact a-c is too late to continue to d, the
+			// detour over b arrives in time.
+			name:   "earlier arrival",
+			edges:  []timedEdge{{"a", "c", 5}, {"a", "b", 1}, {"b", "c", 2}, {"c", "d", 3}},
+			expDia: 3,
+		},
+		{
+			name:   "repeated contact",
+			edges:  []timedEdge{{"a", "b", 5}, {"b", "c", 3}, {"a", "b", 1}},
+			expDia: 2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := New()
+			for _, e := range test.edges {
+				g.AddTimedEdge(e.a, e.b, e.t)
+			}
+			d := g.TemporalDiameter()
+			if d != test.expDia {
+				t.Errorf("Temporal diameter not as expected. Have %d, expected %d", d, test.expDia)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package diameter
+
+import "sync/atomic"
+
+// TrackDiameter makes the graph keep its diameter between changes. Diameter
+// then computes it only after the graph changed, using eccentricity bounds to
+// run far fewer BFS than one from every node, and returns the cached value
+// otherwise. AddEdge, RemoveEdge and RemoveNode mark the diameter as changed
+// unless they don't change any connection. Filling the cache doesn't count as
+// a change, so Diameter may still be called concurrently with other reads,
+// e.g. from ParallelForEachNode. Intended for graphs that are queried more
+// often than changed.
+func (g *Graph) TrackDiameter() {
+	g.tracking = true
+	atomic.StoreInt64(&g.cachedDiameter, -1)
+}
+
+// boundingDiameter returns the diameter of the graph like diameter does,
+// using the BoundingDiameters algorithm of Takes and Kosters on every
+// connected component.
+//
+// A BFS from v gives its eccentricity e(v) and the distances d(v, w), which
+// bound the eccentricity of every other node w of the component:
+//
+//	max(e(v) - d(v, w), d(v, w)) <= e(w) <= e(v) + d(v, w)
+//
+// The diameter is the largest eccentricity, so it lies between the largest
+// lower and the largest upper bound. The BFS alternate between the node with
+// the largest upper bound, which may raise the lower bound, and the node with
+// the smallest lower bound, which as a central node lowers the upper bounds
+// the most, until both bounds meet. Components with fewer nodes than the
+// diameter found so far are skipped.
+func (nodes nodes) boundingDiameter() int {
+	label, sizes := nodes.components()
+	members := make([][]nodeID, len(sizes))
+	for _, id := range nodes.ids() {
+		members[label[id]] = append(members[label[id]], id)
+	}
+
+	var diameter int
+	s := nodes.newBFSScratch()
+	for _, ids := range members {
+		// A component of k nodes can't have a diameter above k-1.
+		if len(ids)-1 <= diameter {
+			continue
+		}
+		if d := nodes.componentDiameter(s, ids); d > diameter {
+			diameter = d
+		}
+	}
+	return diameter
+}
+
+// componentDiameter returns the diameter of the connected component of the
+// given nodes, see boundingDiameter.
+func (nodes nodes) componentDiameter(s *bfsScratch, ids []nodeID) int {
+	lower := make(map[nodeID]int, len(ids))
+	upper := make(map[nodeID]int, len(ids))
+	for _, id := range ids {
+		upper[id] = len(ids) - 1
+	}
+	candidates := ids
+	low, high := 0, len(ids)-1
+	for turn := 0; low < high && len(candidates) > 0; turn++ {
+		v := candidates[0]
+		for _, id := range candidates[1:] {
+			if turn%2 == 0 && upper[id] > upper[v] || turn%2 == 1 && lower[id] < lower[v] {
+				v = id
+			}
+		}
+
+		nodes.bfs(s, v)
+		ecc := s.depth[s.queue[len(s.queue)-1]]
+		if 2*ecc < high {
+			high = 2 * ecc
+		}
+		maxUpper := 0
+		for _, w := range ids {
+			d := s.depth[w]
+			if l := ecc - d; l > lower[w] {
+				lower[w] = l
+			}
+			if d > lower[w] {
+				lower[w] = d
+			}
+			if u := ecc + d; u < upper[w] {
+				upper[w] = u
+			}
+			if lower[w] > low {
+				low = lower[w]
+			}
+			if upper[w] > maxUpper {
+				maxUpper = upper[w]
+			}
+		}
+		s.reset()
+		if maxUpper < high {
+			high = maxUpper
+		}
+
+		// Nodes whose eccentricity is known, or which can neither raise the
+		// lower bound nor lower the upper bound, don't need a BFS.
+		var next []nodeID
+		for _, w := range candidates {
+			if lower[w] == upper[w] || upper[w] <= low && 2*lower[w] >= high {
+				continue
+			}
+			next = append(next, w)
+		}
+		candidates = next
+	}
+	return low
+}
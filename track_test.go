@@ -0,0 +1,109 @@
+package diameter
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestBoundingDiameter(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		// Sparse random graphs, mostly disconnected, with long paths.
+		n := 5 + rnd.Intn(40)
+		m := n + rnd.Intn(n)
+		g := New()
+		for j := 0; j < m; j++ {
+			g.AddEdge(fmt.Sprint(rnd.Intn(n)), fmt.Sprint(rnd.Intn(n)))
+		}
+		t.Run(fmt.Sprintf("%d nodes %d edges", n, m), func(t *testing.T) {
+			if d, exp := g.nodes.boundingDiameter(), g.nodes.diameter(); d != exp {
+				t.Errorf("Diameter not as expected. Have %d, expected %d", d, exp)
+			}
+		})
+	}
+}
+
+func TestTrackDiameter(t *testing.T) {
+	g := New()
+	edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}, {"x", "y"}}.build(g)
+	g.TrackDiameter()
+
+	steps := []struct {
+		name   string
+		change func()
+		exp    int
+	}{
+		{
+			name:   "initial",
+			change: func() {},
+			exp:    3,
+		},
+		{
+			name:   "shortcut",
+			change: func() { g.AddEdge("a", "d") },
+			exp:    2,
+		},
+		{
+			name:   "existing edge",
+			change: func() { g.AddEdge("d", "a") },
+			exp:    2,
+		},
+		{
+			name:   "join components",
+			change: func() { g.AddEdge("d", "x") },
+			exp:    4,
+		},
+		{
+			name:   "remove shortcut",
+			change: func() { g.RemoveEdge("a", "d") },
+			exp:    5,
+		},
+		{
+			name:   "remove unknown edge",
+			change: func() { g.RemoveEdge("a", "y") },
+			exp:    5,
+		},
+		{
+			name:   "isolated node",
+			change: func() { g.AddNode("lonely") },
+			exp:    5,
+		},
+		{
+			name:   "remove node",
+			change: func() { g.RemoveNode("d") },
+			exp:    2,
+		},
+		{
+			name:   "add node again",
+			change: func() { g.AddEdge("d", "lonely"); g.AddEdge("d", "y") },
+			exp:    3,
+		},
+	}
+
+	for _, step := range steps {
+		step.change()
+		g.tracking = false
+		exp := g.Diameter()
+		g.tracking = true
+		if exp != step.exp {
+			t.Fatalf("%s: test expectation wrong. Have %d, expected %d", step.name, exp, step.exp)
+		}
+		if d := g.Diameter(); d != step.exp {
+			t.Errorf("%s: Diameter not as expected. Have %d, expected %d", step.name, d, step.exp)
+		}
+	}
+}
+
+func TestTrackDiameterConcurrentReads(t *testing.T) {
+	g := New()
+	edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}, {"d", "e"}}.build(g)
+	g.TrackDiameter()
+
+	// Run with -race: filling the cache must not race with other readers.
+	g.ParallelForEachNode(func(name string) {
+		if d := g.Diameter(); d != 4 {
+			t.Errorf("Diameter not as expected. Have %d, expected %d", d, 4)
+		}
+	})
+}
@@ -0,0 +1,125 @@
+package diameter
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrInvalidWeight is returned for edge weights that are negative or NaN.
+var ErrInvalidWeight = errors.New("diameter: invalid weight")
+
+// AddWeightedEdge adds a connection between node a and b with weight w, like
+// the length or latency of the connection. The edge is added to the graph
+// like any other edge, the weight is only used by the weighted computations
+// in which edges added without a weight weigh 1. Adding an edge again
+// replaces its weight. It returns an error wrapping ErrInvalidWeight without
+// adding the edge if w is negative or NaN.
+func (g *Graph) AddWeightedEdge(a, b string, w float64) error {
+	if w < 0 || math.IsNaN(w) {
+		return fmt.Errorf("%q-%q: %v: %w", a, b, w, ErrInvalidWeight)
+	}
+	g.AddEdge(a, b)
+	g.weights[newNodePair(g.symbolTable.byName[nodeName(a)], g.symbolTable.byName[nodeName(b)])] = w
+	return nil
+}
+
+// weight returns the weight of the edge between a and b.
+func (g *Graph) weight(a, b nodeID) float64 {
+	if w, ok := g.weights[newNodePair(a, b)]; ok {
+		return w
+	}
+	return 1
+}
+
+// distItem is a node with its tentative distance in the Dijkstra queue.
+type distItem struct {
+	id   nodeID
+	dist float64
+}
+
+// distQueue is a min-heap of nodes by distance for container/heap.
+type distQueue []distItem
+
+func (q distQueue) Len() int { return len(q) }
+func (q distQueue) Less(i, j int) bool {
+	if q[i].dist != q[j].dist {
+		return q[i].dist < q[j].dist
+	}
+	return q[i].id < q[j].id
+}
+func (q distQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *distQueue) Push(x interface{}) { *q = append(*q, x.(distItem)) }
+func (q *distQueue) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
+
+// dijkstra returns the weighted distances from the start node to every node
+// it can reach, and the parent of every reached node on a shortest path to
+// it, with start as its own parent. Nodes already settled are skipped when
+// popped again instead of decreasing their key in the queue.
+func (g *Graph) dijkstra(start nodeID) (map[nodeID]float64, map[nodeID]nodeID) {
+	dist := map[nodeID]float64{start: 0}
+	parent := map[nodeID]nodeID{start: start}
+	done := make(map[nodeID]bool, len(g.nodes))
+	q := &distQueue{{id: start}}
+	for q.Len() > 0 {
+		item := heap.Pop(q).(distItem)
+		if done[item.id] {
+			continue
+		}
+		done[item.id] = true
+		for _, adj := range g.nodes.neighbors(item.id) {
+			d := item.dist + g.weight(item.id, adj)
+			if old, ok := dist[adj]; !ok || d < old {
+				dist[adj] = d
+				parent[adj] = item.id
+				heap.Push(q, distItem{id: adj, dist: d})
+			}
+		}
+	}
+	return dist, parent
+}
+
+// WeightedDiameter returns the largest weighted distance between any two
+// nodes that can reach each other, where the weighted distance is the
+// smallest sum of edge weights along a path. Edges added without a weight
+// weigh 1, so without weighted edges it equals Diameter, which is then used
+// as the faster BFS computes the same.
+func (g *Graph) WeightedDiameter() float64 {
+	if len(g.weights) == 0 {
+		return float64(g.Diameter())
+	}
+	var diameter float64
+	for id := range g.nodes {
+		dist, _ := g.dijkstra(id)
+		for _, d := range dist {
+			diameter = math.Max(diameter, d)
+		}
+	}
+	return diameter
+}
+
+// WeightedShortestPath returns a path from node a to node b with the smallest
+// sum of edge weights, including both ends, and that sum. It returns an error
+// wrapping ErrNodeNotFound if either node is unknown, or ErrNoPath if b can't
+// be reached from a.
+func (g *Graph) WeightedShortestPath(a, b string) ([]string, float64, error) {
+	from, err := g.find(a)
+	if err != nil {
+		return nil, 0, err
+	}
+	to, err := g.find(b)
+	if err != nil {
+		return nil, 0, err
+	}
+	dist, parent := g.dijkstra(from)
+	if _, ok := dist[to]; !ok {
+		return nil, 0, fmt.Errorf("%q to %q: %w", a, b, ErrNoPath)
+	}
+	return g.symbolTable.toStrings(pathTo(parent, to)), dist[to], nil
+}
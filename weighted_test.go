@@ -0,0 +1,132 @@
+package diameter
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+// latency builds a square a,b,c,d with a slow direct link a-c and a cheap
+// detour over e between a and d.
+func latency(t *testing.T) *Graph {
+	g := New()
+	for _, e := range []struct {
+		a, b string
+		w    float64
+	}{
+		{"a", "b", 1}, {"b", "c", 2}, {"c", "d", 1}, {"d", "a", 5},
+		{"a", "c", 10}, {"a", "e", 0.5}, {"e", "d", 0.5},
+	} {
+		if err := g.AddWeightedEdge(e.a, e.b, e.w); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return g
+}
+
+func TestAddWeightedEdge(t *testing.T) {
+	g := New()
+	for _, w := range []float64{-1, math.NaN()} {
+		if err := g.AddWeightedEdge("a", "b", w); !errors.Is(err, ErrInvalidWeight) {
+			t.Errorf("Expected error to wrap ErrInvalidWeight for %v, have %v", w, err)
+		}
+	}
+	if len(g.nodes) != 0 {
+		t.Errorf("Expected invalid edges not to be added")
+	}
+
+	g.AddWeightedEdge("a", "b", 3)
+	g.AddWeightedEdge("b", "a", 2)
+	g.AddEdge("b", "c")
+	if w := g.WeightedDiameter(); w != 3 {
+		t.Errorf("Weighted diameter not as expected. Have %f, expected %f", w, 3.0)
+	}
+}
+
+func TestWeightedShortestPath(t *testing.T) {
+	g := latency(t)
+	g.AddEdge("x", "y")
+
+	tests := []struct {
+		name      string
+		from, to  string
+		expPath   []string
+		expLength float64
+		expErr    error
+	}{
+		{
+			name:    "itself",
+			from:    "a",
+			to:      "a",
+			expPath: []string{"a"},
+		},
+		{
+			name:      "avoid the slow link",
+			from:      "a",
+			to:        "c",
+			expPath:   []string{"a", "e", "d", "c"},
+			expLength: 2,
+		},
+		{
+			name:      "detour",
+			from:      "d",
+			to:        "b",
+			expPath:   []string{"d", "e", "a", "b"},
+			expLength: 2,
+		},
+		{
+			name:      "unweighted edge",
+			from:      "y",
+			to:        "x",
+			expPath:   []string{"y", "x"},
+			expLength: 1,
+		},
+		{
+			name:   "disconnected",
+			from:   "a",
+			to:     "x",
+			expErr: ErrNoPath,
+		},
+		{
+			name:   "unknown",
+			from:   "a",
+			to:     "q",
+			expErr: ErrNodeNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path, length, err := g.WeightedShortestPath(test.from, test.to)
+			if !errors.Is(err, test.expErr) {
+				t.Fatalf("Error not as expected. Have %v, expected %v", err, test.expErr)
+			}
+			if length != test.expLength {
+				t.Errorf("Length not as expected. Have %f, expected %f", length, test.expLength)
+			}
+			if fmt.Sprint(path) != fmt.Sprint(test.expPath) {
+				t.Errorf("Path not as expected. Have %v, expected %v", path, test.expPath)
+			}
+		})
+	}
+}
+
+func TestWeightedDiameter(t *testing.T) {
+	// The farthest pairs a-c, b-c and b-d are all 2 apart, even though a and c
+	// are directly linked.
+	if w := latency(t).WeightedDiameter(); w != 2 {
+		t.Errorf("Weighted diameter not as expected. Have %f, expected %f", w, 2.0)
+	}
+
+	g := New()
+	edgeList{{"a", "b"}, {"b", "c"}, {"c", "d"}, {"x", "y"}}.build(g)
+	if w := g.WeightedDiameter(); w != float64(g.Diameter()) {
+		t.Errorf("Weighted diameter not as expected. Have %f, expected %d", w, g.Diameter())
+	}
+	g.AddWeightedEdge("x", "y", 1)
+	g.AddWeightedEdge("b", "c", 1)
+	if w := g.WeightedDiameter(); w != float64(g.Diameter()) {
+		t.Errorf("Weighted diameter with unit weights not as expected. Have %f, expected %d", w, g.Diameter())
+	}
+}